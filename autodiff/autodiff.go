@@ -0,0 +1,352 @@
+// Package autodiff 基于计算带（tape）的反向模式自动微分。
+//
+// Var 包装一个矩阵，对 Var 的运算被依次记录在所属的 Tape 上；对 1x1 的标量结果调用 Backward，
+// 沿记录的逆序传播，把损失对各个 Var 的梯度累加到 Grad 中。
+//
+//	t := autodiff.NewTape()
+//	W, x, y := t.Var(W0), t.Var(x0), t.Var(y0)
+//	loss := W.Dot(x).Tanh().Sub(y).Norm()
+//	loss.Backward()
+//	// W.Grad 即 d loss / d W
+package autodiff
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/mrfyo/matrix"
+)
+
+// Tape 计算带，记录运算的先后顺序
+type Tape struct {
+	vars []*Var
+}
+
+// Var 计算图中的一个变量，Grad 与 Value 形状相同
+type Var struct {
+	Value matrix.Matrix
+	Grad  matrix.Matrix
+
+	tape *Tape
+	// backward 将本变量的梯度传播给输入，叶子变量为 nil
+	backward func()
+}
+
+// NewTape 创建空的计算带
+func NewTape() *Tape {
+	return &Tape{}
+}
+
+// Var 创建叶子变量，通常是模型参数或输入。A 不会被复制
+func (t *Tape) Var(A matrix.Matrix) *Var {
+	v := &Var{Value: A, Grad: matrix.Zeros(A.Shape), tape: t}
+	t.vars = append(t.vars, v)
+	return v
+}
+
+// ZeroGrad 将所有变量的梯度清零
+func (t *Tape) ZeroGrad() {
+	for _, v := range t.vars {
+		matrix.MatrixScaleMul(v.Grad, 0)
+	}
+}
+
+// Reset 丢弃记录的运算，只保留叶子变量并清零其梯度，用于训练循环的每一步
+func (t *Tape) Reset() {
+	leaves := t.vars[:0]
+	for _, v := range t.vars {
+		if v.backward == nil {
+			matrix.MatrixScaleMul(v.Grad, 0)
+			leaves = append(leaves, v)
+		}
+	}
+	for i := len(leaves); i < len(t.vars); i++ {
+		t.vars[i] = nil
+	}
+	t.vars = leaves
+}
+
+// Len 返回计算带上的变量个数
+func (t *Tape) Len() int {
+	return len(t.vars)
+}
+
+func (t *Tape) push(value matrix.Matrix) *Var {
+	v := &Var{Value: value, Grad: matrix.Zeros(value.Shape), tape: t}
+	t.vars = append(t.vars, v)
+	return v
+}
+
+// Shape 返回变量形状
+func (v *Var) Shape() matrix.Shape {
+	return v.Value.Shape
+}
+
+// Scalar 返回 1x1 变量的值
+func (v *Var) Scalar() float64 {
+	if v.Value.Row != 1 || v.Value.Col != 1 {
+		panic(fmt.Sprintf("Scalar(): value must be 1x1, got %v.", v.Value.Shape))
+	}
+	return v.Value.GetIndex(0)
+}
+
+// Backward 从 1x1 的结果反向传播，梯度累加到叶子变量的 Grad。
+// 中间变量的梯度每次重新计算，因此可以对同一个图多次调用
+func (v *Var) Backward() {
+	if v.Value.Row != 1 || v.Value.Col != 1 {
+		panic(fmt.Sprintf("Backward(): value must be 1x1, got %v.", v.Value.Shape))
+	}
+	vars := v.tape.vars
+	k := len(vars) - 1
+	for k >= 0 && vars[k] != v {
+		k--
+	}
+	if k < 0 {
+		panic("Backward(): variable is not on its tape, was the tape reset?")
+	}
+
+	for _, u := range vars[:k+1] {
+		if u.backward != nil {
+			matrix.MatrixScaleMul(u.Grad, 0)
+		}
+	}
+	v.Grad.SetIndex(0, 1)
+	for ; k >= 0; k-- {
+		if vars[k].backward != nil {
+			vars[k].backward()
+		}
+	}
+}
+
+func (v *Var) sameTape(w *Var) {
+	if v.tape != w.tape {
+		panic("autodiff: variables belong to different tapes.")
+	}
+}
+
+// Add 矩阵相加
+func (v *Var) Add(w *Var) *Var {
+	v.sameTape(w)
+	r := v.tape.push(v.Value.Add(w.Value))
+	r.backward = func() {
+		matrix.MatrixAdd(v.Grad, r.Grad)
+		matrix.MatrixAdd(w.Grad, r.Grad)
+	}
+	return r
+}
+
+// Sub 矩阵相减
+func (v *Var) Sub(w *Var) *Var {
+	v.sameTape(w)
+	r := v.tape.push(v.Value.Sub(w.Value))
+	r.backward = func() {
+		matrix.MatrixAdd(v.Grad, r.Grad)
+		matrix.MatrixSub(w.Grad, r.Grad)
+	}
+	return r
+}
+
+// Mul 点乘(同位置相乘，形状不变)
+func (v *Var) Mul(w *Var) *Var {
+	v.sameTape(w)
+	r := v.tape.push(v.Value.Mul(w.Value))
+	r.backward = func() {
+		matrix.MatrixAdd(v.Grad, r.Grad.Mul(w.Value))
+		matrix.MatrixAdd(w.Grad, r.Grad.Mul(v.Value))
+	}
+	return r
+}
+
+// Dot 矩阵乘法
+func (v *Var) Dot(w *Var) *Var {
+	v.sameTape(w)
+	r := v.tape.push(v.Value.Dot(w.Value))
+	r.backward = func() {
+		matrix.MatrixAdd(v.Grad, r.Grad.Dot(w.Value.T()))
+		matrix.MatrixAdd(w.Grad, v.Value.T().Dot(r.Grad))
+	}
+	return r
+}
+
+// AddRow 每一行加上行向量 b，常用于偏置
+func (v *Var) AddRow(b *Var) *Var {
+	v.sameTape(b)
+	if b.Value.Row != 1 || b.Value.Col != v.Value.Col {
+		panic(fmt.Sprintf("AddRow(b): b must be a 1x%d row vector, got %v.", v.Value.Col, b.Value.Shape))
+	}
+	S := v.Value.Copy()
+	for i := 0; i < S.Row; i++ {
+		for j := 0; j < S.Col; j++ {
+			S.Set(i, j, S.Get(i, j)+b.Value.GetIndex(j))
+		}
+	}
+	r := v.tape.push(S)
+	r.backward = func() {
+		matrix.MatrixAdd(v.Grad, r.Grad)
+		for i := 0; i < r.Grad.Row; i++ {
+			for j := 0; j < r.Grad.Col; j++ {
+				b.Grad.SetIndex(j, b.Grad.GetIndex(j)+r.Grad.Get(i, j))
+			}
+		}
+	}
+	return r
+}
+
+// T 转置
+func (v *Var) T() *Var {
+	r := v.tape.push(v.Value.T())
+	r.backward = func() {
+		matrix.MatrixAdd(v.Grad, r.Grad.T())
+	}
+	return r
+}
+
+// ScaleMul 矩阵比例乘
+func (v *Var) ScaleMul(k float64) *Var {
+	r := v.tape.push(v.Value.ScaleMul(k))
+	r.backward = func() {
+		matrix.MatrixAdd(v.Grad, r.Grad.ScaleMul(k))
+	}
+	return r
+}
+
+// Norm 2-范数（矩阵为 F-范数），结果为 1x1。范数为 0 时梯度取 0
+func (v *Var) Norm() *Var {
+	d := matrix.Norm(v.Value)
+	r := v.tape.push(matrix.NewVector([]float64{d}, 1))
+	r.backward = func() {
+		if d == 0 {
+			return
+		}
+		matrix.MatrixAdd(v.Grad, v.Value.ScaleMul(r.Grad.GetIndex(0)/d))
+	}
+	return r
+}
+
+// Inner 向量内积，结果为 1x1
+func (v *Var) Inner(w *Var) *Var {
+	v.sameTape(w)
+	r := v.tape.push(matrix.NewVector([]float64{matrix.Inner(v.Value, w.Value)}, 1))
+	r.backward = func() {
+		g := r.Grad.GetIndex(0)
+		for i := 0; i < v.Value.Size(); i++ {
+			v.Grad.SetIndex(i, v.Grad.GetIndex(i)+g*w.Value.GetIndex(i))
+			w.Grad.SetIndex(i, w.Grad.GetIndex(i)+g*v.Value.GetIndex(i))
+		}
+	}
+	return r
+}
+
+// Sum 所有元素之和，结果为 1x1
+func (v *Var) Sum() *Var {
+	s := 0.0
+	for i := 0; i < v.Value.Size(); i++ {
+		s += v.Value.GetIndex(i)
+	}
+	r := v.tape.push(matrix.NewVector([]float64{s}, 1))
+	r.backward = func() {
+		matrix.MatrixAdd(v.Grad, matrix.Full(v.Value.Shape, r.Grad.GetIndex(0)))
+	}
+	return r
+}
+
+// Mean 所有元素的平均值，结果为 1x1
+func (v *Var) Mean() *Var {
+	return v.Sum().ScaleMul(1 / float64(v.Value.Size()))
+}
+
+// ColSum 各列之和，结果为 1xCol 行向量
+func (v *Var) ColSum() *Var {
+	A := v.Value
+	S := matrix.Zeros(matrix.Shape{Row: 1, Col: A.Col})
+	for i := 0; i < A.Row; i++ {
+		for j := 0; j < A.Col; j++ {
+			S.SetIndex(j, S.GetIndex(j)+A.Get(i, j))
+		}
+	}
+	r := v.tape.push(S)
+	r.backward = func() {
+		for i := 0; i < A.Row; i++ {
+			for j := 0; j < A.Col; j++ {
+				v.Grad.Set(i, j, v.Grad.Get(i, j)+r.Grad.GetIndex(j))
+			}
+		}
+	}
+	return r
+}
+
+// RowSum 各行之和，结果为 Rowx1 列向量
+func (v *Var) RowSum() *Var {
+	A := v.Value
+	S := matrix.Zeros(matrix.Shape{Row: A.Row, Col: 1})
+	for i := 0; i < A.Row; i++ {
+		for j := 0; j < A.Col; j++ {
+			S.SetIndex(i, S.GetIndex(i)+A.Get(i, j))
+		}
+	}
+	r := v.tape.push(S)
+	r.backward = func() {
+		for i := 0; i < A.Row; i++ {
+			for j := 0; j < A.Col; j++ {
+				v.Grad.Set(i, j, v.Grad.Get(i, j)+r.Grad.GetIndex(i))
+			}
+		}
+	}
+	return r
+}
+
+// apply 逐元素函数，df 由输入 x 与输出 y 计算导数
+func (v *Var) apply(f func(x float64) float64, df func(x, y float64) float64) *Var {
+	S := matrix.Zeros(v.Value.Shape)
+	for i := 0; i < S.Size(); i++ {
+		S.SetIndex(i, f(v.Value.GetIndex(i)))
+	}
+	r := v.tape.push(S)
+	r.backward = func() {
+		for i := 0; i < S.Size(); i++ {
+			d := df(v.Value.GetIndex(i), S.GetIndex(i))
+			v.Grad.SetIndex(i, v.Grad.GetIndex(i)+r.Grad.GetIndex(i)*d)
+		}
+	}
+	return r
+}
+
+// Map 逐元素函数，df 为 f 的导数
+func (v *Var) Map(f, df func(x float64) float64) *Var {
+	return v.apply(f, func(x, _ float64) float64 { return df(x) })
+}
+
+// Exp 逐元素 e^x
+func (v *Var) Exp() *Var {
+	return v.apply(math.Exp, func(_, y float64) float64 { return y })
+}
+
+// Log 逐元素自然对数
+func (v *Var) Log() *Var {
+	return v.apply(math.Log, func(x, _ float64) float64 { return 1 / x })
+}
+
+// Square 逐元素平方
+func (v *Var) Square() *Var {
+	return v.apply(func(x float64) float64 { return x * x }, func(x, _ float64) float64 { return 2 * x })
+}
+
+// Tanh 逐元素双曲正切
+func (v *Var) Tanh() *Var {
+	return v.apply(math.Tanh, func(_, y float64) float64 { return 1 - y*y })
+}
+
+// Sigmoid 逐元素 1 / (1 + e^-x)
+func (v *Var) Sigmoid() *Var {
+	return v.apply(func(x float64) float64 { return 1 / (1 + math.Exp(-x)) }, func(_, y float64) float64 { return y * (1 - y) })
+}
+
+// ReLU 逐元素 max(0, x)，x = 0 处导数取 0
+func (v *Var) ReLU() *Var {
+	return v.apply(func(x float64) float64 { return math.Max(0, x) }, func(x, _ float64) float64 {
+		if x > 0 {
+			return 1
+		}
+		return 0
+	})
+}
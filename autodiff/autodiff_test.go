@@ -0,0 +1,147 @@
+package autodiff
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/mrfyo/matrix"
+)
+
+// checkGrad 用中心差分检查 loss 对各参数的梯度
+func checkGrad(t *testing.T, name string, params []matrix.Matrix, loss func(t *Tape, xs []*Var) *Var) {
+	t.Helper()
+	tape := NewTape()
+	xs := make([]*Var, len(params))
+	for i, P := range params {
+		xs[i] = tape.Var(P)
+	}
+	loss(tape, xs).Backward()
+
+	value := func() float64 {
+		tape := NewTape()
+		xs := make([]*Var, len(params))
+		for i, P := range params {
+			xs[i] = tape.Var(P)
+		}
+		return loss(tape, xs).Scalar()
+	}
+
+	const h = 1e-6
+	for p, P := range params {
+		for i := 0; i < P.Size(); i++ {
+			x := P.GetIndex(i)
+			P.SetIndex(i, x+h)
+			fp := value()
+			P.SetIndex(i, x-h)
+			fm := value()
+			P.SetIndex(i, x)
+
+			want := (fp - fm) / (2 * h)
+			got := xs[p].Grad.GetIndex(i)
+			if math.Abs(got-want) > 1e-6*math.Max(1, math.Abs(want)) {
+				t.Errorf("%s: d/dx%d[%d] = %v, finite difference %v", name, p, i, got, want)
+			}
+		}
+	}
+}
+
+func TestGradients(t *testing.T) {
+	src := rand.NewSource(1)
+	randn := func(r, c int) matrix.Matrix {
+		return matrix.Randn(matrix.Shape{Row: r, Col: c}, src)
+	}
+	positive := func(r, c int) matrix.Matrix {
+		return matrix.Rand(matrix.Shape{Row: r, Col: c}, src).Add(matrix.Full(matrix.Shape{Row: r, Col: c}, 0.5))
+	}
+
+	cases := []struct {
+		name   string
+		params []matrix.Matrix
+		loss   func(t *Tape, xs []*Var) *Var
+	}{
+		{"add-sub", []matrix.Matrix{randn(2, 3), randn(2, 3)}, func(_ *Tape, x []*Var) *Var {
+			return x[0].Add(x[1]).Sub(x[1].ScaleMul(3)).Square().Sum()
+		}},
+		{"mul", []matrix.Matrix{randn(3, 2), randn(3, 2)}, func(_ *Tape, x []*Var) *Var {
+			return x[0].Mul(x[1]).Mul(x[0]).Sum()
+		}},
+		{"dot-transpose", []matrix.Matrix{randn(2, 3), randn(2, 4)}, func(_ *Tape, x []*Var) *Var {
+			return x[0].T().Dot(x[1]).Square().Mean()
+		}},
+		{"norm", []matrix.Matrix{randn(3, 3)}, func(_ *Tape, x []*Var) *Var {
+			return x[0].Dot(x[0]).Norm()
+		}},
+		{"inner", []matrix.Matrix{randn(4, 1), randn(1, 4)}, func(_ *Tape, x []*Var) *Var {
+			return x[0].Tanh().Inner(x[1].Sigmoid())
+		}},
+		{"exp-log", []matrix.Matrix{positive(2, 2)}, func(_ *Tape, x []*Var) *Var {
+			return x[0].Log().Mul(x[0].ScaleMul(-0.5).Exp()).Sum()
+		}},
+		{"relu", []matrix.Matrix{randn(3, 3)}, func(_ *Tape, x []*Var) *Var {
+			return x[0].ReLU().Dot(x[0]).Sum()
+		}},
+		{"reductions", []matrix.Matrix{randn(3, 4)}, func(_ *Tape, x []*Var) *Var {
+			return x[0].ColSum().Square().Sum().Add(x[0].RowSum().Exp().Sum())
+		}},
+		{"map", []matrix.Matrix{randn(2, 3)}, func(_ *Tape, x []*Var) *Var {
+			return x[0].Map(math.Sin, math.Cos).Sum()
+		}},
+		// 两层网络的均方误差
+		{"mlp", []matrix.Matrix{randn(5, 3), randn(3, 4), randn(1, 4), randn(4, 1), randn(5, 1)}, func(_ *Tape, x []*Var) *Var {
+			X, W1, b1, W2, y := x[0], x[1], x[2], x[3], x[4]
+			return X.Dot(W1).AddRow(b1).Tanh().Dot(W2).Sub(y).Square().Mean()
+		}},
+	}
+	for _, c := range cases {
+		checkGrad(t, c.name, c.params, c.loss)
+	}
+}
+
+func TestBackwardTwiceAndReset(t *testing.T) {
+	tape := NewTape()
+	x := tape.Var(matrix.NewVector([]float64{1, 2}, 1))
+	loss := x.Square().Sum()
+
+	loss.Backward()
+	loss.Backward()
+	// 叶子梯度累加两次：2 * 2x
+	if want := matrix.NewVector([]float64{4, 8}, 1); !matrix.MatrixEqual(x.Grad, want) {
+		t.Errorf("after two Backward: grad %v, want %v", x.Grad, want)
+	}
+
+	tape.Reset()
+	if tape.Len() != 1 || x.Grad.GetIndex(0) != 0 {
+		t.Fatalf("Reset: %d vars, grad %v", tape.Len(), x.Grad)
+	}
+	x.ScaleMul(3).Sum().Backward()
+	if want := matrix.NewVector([]float64{3, 3}, 1); !matrix.MatrixEqual(x.Grad, want) {
+		t.Errorf("after Reset: grad %v, want %v", x.Grad, want)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Backward on non-scalar: expected panic")
+		}
+	}()
+	x.Backward()
+}
+
+func TestGradientDescent(t *testing.T) {
+	// 最小二乘 min |Ax - b|，梯度下降应收敛到正规方程的解
+	A := matrix.NewMatrix(matrix.Shape{Row: 3, Col: 2}, []float64{1, 1, 1, 2, 1, 3})
+	b := matrix.NewVector([]float64{1, 2, 2}, 1)
+	want := matrix.Solve(A.T().Dot(A), A.T().Dot(b))
+
+	tape := NewTape()
+	a, y := tape.Var(A), tape.Var(b)
+	x := tape.Var(matrix.Zeros(matrix.Shape{Row: 2, Col: 1}))
+	for step := 0; step < 2000; step++ {
+		tape.Reset()
+		a.Dot(x).Sub(y).Square().Sum().Backward()
+		matrix.MatrixSub(x.Value, x.Grad.ScaleMul(0.05))
+	}
+	if !matrix.MatrixEqual(x.Value, want) {
+		t.Errorf("gradient descent: %v, want %v", x.Value, want)
+	}
+}
@@ -0,0 +1,214 @@
+package matrix
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math"
+)
+
+// 二进制格式：
+//
+//	magic   [4]byte  "GMAT"
+//	version uint8    1
+//	dtype   uint8    1 = float64
+//	        [2]byte  保留
+//	row     uint64
+//	col     uint64
+//	data    row*col 个 float64，行优先
+//	crc     uint32   以上全部字节的 CRC-32 (IEEE)
+//
+// 所有整数与浮点数均为小端序。
+const (
+	binaryMagic      = "GMAT"
+	binaryVersion    = 1
+	binaryFloat64    = 1
+	binaryHeaderSize = 24
+)
+
+var (
+	// ErrBinaryFormat 二进制数据格式错误
+	ErrBinaryFormat = errors.New("matrix: invalid binary format")
+	// ErrBinaryChecksum 二进制数据校验失败
+	ErrBinaryChecksum = errors.New("matrix: binary checksum mismatch")
+)
+
+// MarshalBinary 实现 encoding.BinaryMarshaler
+func (A Matrix) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.Grow(binaryHeaderSize + 8*A.Size() + 4)
+	if _, err := A.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary 实现 encoding.BinaryUnmarshaler，数据长度必须恰好为一个矩阵
+func (A *Matrix) UnmarshalBinary(data []byte) error {
+	if len(data) < binaryHeaderSize+4 {
+		return fmt.Errorf("%w: truncated input", ErrBinaryFormat)
+	}
+	shape, err := parseBinaryHeader(data[:binaryHeaderSize])
+	if err != nil {
+		return err
+	}
+	if uint64(len(data)-binaryHeaderSize-4)/8 != uint64(shape.Size()) || (len(data)-binaryHeaderSize-4)%8 != 0 {
+		return fmt.Errorf("%w: data length not match shape %v", ErrBinaryFormat, shape)
+	}
+
+	body := data[:len(data)-4]
+	if crc32.ChecksumIEEE(body) != binary.LittleEndian.Uint32(data[len(data)-4:]) {
+		return ErrBinaryChecksum
+	}
+
+	array := make([]float64, shape.Size())
+	for i := range array {
+		array[i] = math.Float64frombits(binary.LittleEndian.Uint64(body[binaryHeaderSize+8*i:]))
+	}
+	*A = NewMatrix(shape, array)
+	return nil
+}
+
+// GobEncode 实现 gob.GobEncoder
+func (A Matrix) GobEncode() ([]byte, error) {
+	return A.MarshalBinary()
+}
+
+// GobDecode 实现 gob.GobDecoder
+func (A *Matrix) GobDecode(data []byte) error {
+	return A.UnmarshalBinary(data)
+}
+
+// WriteTo 实现 io.WriterTo，以二进制格式写出矩阵
+func (A Matrix) WriteTo(w io.Writer) (int64, error) {
+	h := crc32.NewIEEE()
+	cw := &countWriter{w: io.MultiWriter(w, h)}
+
+	header := make([]byte, binaryHeaderSize)
+	copy(header, binaryMagic)
+	header[4] = binaryVersion
+	header[5] = binaryFloat64
+	binary.LittleEndian.PutUint64(header[8:], uint64(A.Row))
+	binary.LittleEndian.PutUint64(header[16:], uint64(A.Col))
+	if _, err := cw.Write(header); err != nil {
+		return cw.n, err
+	}
+
+	// 分块写出数据
+	chunk := make([]byte, 0, 8*1024)
+	for i := 0; i < A.Size(); i++ {
+		var b [8]byte
+		binary.LittleEndian.PutUint64(b[:], math.Float64bits(A.GetIndex(i)))
+		chunk = append(chunk, b[:]...)
+		if len(chunk) == cap(chunk) {
+			if _, err := cw.Write(chunk); err != nil {
+				return cw.n, err
+			}
+			chunk = chunk[:0]
+		}
+	}
+	if _, err := cw.Write(chunk); err != nil {
+		return cw.n, err
+	}
+
+	var sum [4]byte
+	binary.LittleEndian.PutUint32(sum[:], h.Sum32())
+	n, err := w.Write(sum[:])
+	return cw.n + int64(n), err
+}
+
+// ReadFrom 实现 io.ReaderFrom，从 r 中读取恰好一个二进制矩阵
+func (A *Matrix) ReadFrom(r io.Reader) (int64, error) {
+	h := crc32.NewIEEE()
+	cr := &countReader{r: io.TeeReader(r, h)}
+
+	header := make([]byte, binaryHeaderSize)
+	if _, err := io.ReadFull(cr, header); err != nil {
+		return cr.n, truncated(err)
+	}
+	shape, err := parseBinaryHeader(header)
+	if err != nil {
+		return cr.n, err
+	}
+
+	// 分块读取，截断的输入不会导致按声明的形状一次性分配内存
+	array := make([]float64, 0)
+	chunk := make([]byte, 8*1024)
+	for remain := shape.Size(); remain > 0; {
+		n := remain
+		if n > len(chunk)/8 {
+			n = len(chunk) / 8
+		}
+		if _, err := io.ReadFull(cr, chunk[:8*n]); err != nil {
+			return cr.n, truncated(err)
+		}
+		for i := 0; i < n; i++ {
+			array = append(array, math.Float64frombits(binary.LittleEndian.Uint64(chunk[8*i:])))
+		}
+		remain -= n
+	}
+
+	want := h.Sum32()
+	var sum [4]byte
+	n, err := io.ReadFull(r, sum[:])
+	if err != nil {
+		return cr.n + int64(n), truncated(err)
+	}
+	if binary.LittleEndian.Uint32(sum[:]) != want {
+		return cr.n + int64(n), ErrBinaryChecksum
+	}
+
+	*A = NewMatrix(shape, array)
+	return cr.n + int64(n), nil
+}
+
+func parseBinaryHeader(header []byte) (Shape, error) {
+	if string(header[:4]) != binaryMagic {
+		return Shape{}, fmt.Errorf("%w: bad magic", ErrBinaryFormat)
+	}
+	if header[4] != binaryVersion {
+		return Shape{}, fmt.Errorf("%w: unsupported version %d", ErrBinaryFormat, header[4])
+	}
+	if header[5] != binaryFloat64 {
+		return Shape{}, fmt.Errorf("%w: unsupported dtype %d", ErrBinaryFormat, header[5])
+	}
+
+	row := binary.LittleEndian.Uint64(header[8:])
+	col := binary.LittleEndian.Uint64(header[16:])
+	if !decodeShapeOK(row, col) {
+		return Shape{}, fmt.Errorf("%w: shape (%d, %d) too large", ErrBinaryFormat, row, col)
+	}
+	return Shape{Row: int(row), Col: int(col)}, nil
+}
+
+func truncated(err error) error {
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return fmt.Errorf("%w: truncated input", ErrBinaryFormat)
+	}
+	return err
+}
+
+type countWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+type countReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,84 @@
+package matrix
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"testing"
+)
+
+func TestMarshalBinary(t *testing.T) {
+	A := Builder().Row().Link(1, 2.5, -3).Link(4, 5, 6e10).Build()
+
+	data, err := A.MarshalBinary()
+	if err != nil || len(data) != binaryHeaderSize+8*A.Size()+4 {
+		t.Fatal("error method: MarshalBinary")
+	}
+
+	var B Matrix
+	if err := B.UnmarshalBinary(data); err != nil || !MatrixEqual(A, B) {
+		t.Error("error method: UnmarshalBinary")
+	}
+
+	// 截断或被篡改的数据
+	if err := B.UnmarshalBinary(data[:len(data)-1]); !errors.Is(err, ErrBinaryFormat) {
+		t.Errorf("error method: UnmarshalBinary truncated: %v", err)
+	}
+	corrupt := append([]byte(nil), data...)
+	corrupt[binaryHeaderSize+3] ^= 0xff
+	if err := B.UnmarshalBinary(corrupt); !errors.Is(err, ErrBinaryChecksum) {
+		t.Errorf("error method: UnmarshalBinary corrupt: %v", err)
+	}
+}
+
+func TestWriteToReadFrom(t *testing.T) {
+	A := Builder().Row().Link(1, 2).Link(3, 4).Build()
+	B := Eye(3)
+
+	var buf bytes.Buffer
+	n1, err1 := A.WriteTo(&buf)
+	n2, err2 := B.WriteTo(&buf)
+	if err1 != nil || err2 != nil || n1+n2 != int64(buf.Len()) {
+		t.Fatal("error method: WriteTo")
+	}
+
+	var C, D Matrix
+	m1, err1 := C.ReadFrom(&buf)
+	m2, err2 := D.ReadFrom(&buf)
+	if err1 != nil || err2 != nil || m1 != n1 || m2 != n2 || !MatrixEqual(A, C) || !MatrixEqual(B, D) {
+		t.Error("error method: ReadFrom")
+	}
+
+	data, _ := A.MarshalBinary()
+	if _, err := C.ReadFrom(bytes.NewReader(data[:30])); !errors.Is(err, ErrBinaryFormat) {
+		t.Errorf("error method: ReadFrom truncated: %v", err)
+	}
+
+	// 头部声明超出解码限制或乘积溢出的形状
+	for _, dims := range [][2]uint64{{1 << 31, 1}, {1 << 14, 1 << 14}, {1 << 30, 1 << 30}, {1<<32 + 1, 1 << 32}} {
+		huge := append([]byte(nil), data[:binaryHeaderSize]...)
+		binary.LittleEndian.PutUint64(huge[8:], dims[0])
+		binary.LittleEndian.PutUint64(huge[16:], dims[1])
+		if _, err := C.ReadFrom(bytes.NewReader(huge)); !errors.Is(err, ErrBinaryFormat) {
+			t.Errorf("error method: ReadFrom shape %v: %v", dims, err)
+		}
+	}
+}
+
+func TestGob(t *testing.T) {
+	type payload struct {
+		Name string
+		M    Matrix
+	}
+	in := payload{Name: "a", M: Builder().Row().Link(1, 2, 3).Build()}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+		t.Fatal(err)
+	}
+	var out payload
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil || out.Name != "a" || !MatrixEqual(in.M, out.M) {
+		t.Error("error method: GobEncode/GobDecode")
+	}
+}
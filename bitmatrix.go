@@ -0,0 +1,352 @@
+package matrix
+
+import (
+	"fmt"
+	"math/bits"
+	"strings"
+)
+
+// BitMatrix GF(2) 上的矩阵，每行按位压缩存储在 uint64 中，行运算以整字异或完成
+type BitMatrix struct {
+	Shape
+	// stride 每行占用的字数
+	stride int
+	words  []uint64
+}
+
+// NewBitMatrix 零矩阵
+func NewBitMatrix(shape Shape) BitMatrix {
+	stride := (shape.Col + 63) / 64
+	return BitMatrix{Shape: shape, stride: stride, words: make([]uint64, shape.Row*stride)}
+}
+
+// BitEye 单位矩阵
+func BitEye(n int) BitMatrix {
+	A := NewBitMatrix(Shape{n, n})
+	for i := 0; i < n; i++ {
+		A.Set(i, i, true)
+	}
+	return A
+}
+
+// ParseBitMatrix 解析由 0、1 组成的矩阵，行之间以 ; 或换行分隔，忽略空白与方括号，如 "[1011; 0110]"
+func ParseBitMatrix(s string) (BitMatrix, error) {
+	s = strings.Trim(strings.TrimSpace(s), "[]")
+	lines := strings.FieldsFunc(s, func(r rune) bool { return r == ';' || r == '\n' })
+	rows := make([][]bool, 0, len(lines))
+	for _, line := range lines {
+		var row []bool
+		for _, c := range line {
+			switch c {
+			case '0', '1':
+				row = append(row, c == '1')
+			case ' ', '\t', '\r', ',':
+			default:
+				return BitMatrix{}, fmt.Errorf("matrix: invalid bit %q in row %d", c, len(rows)+1)
+			}
+		}
+		if len(row) == 0 {
+			continue
+		}
+		if len(rows) > 0 && len(row) != len(rows[0]) {
+			return BitMatrix{}, fmt.Errorf("matrix: row %d has %d bits, expect %d", len(rows)+1, len(row), len(rows[0]))
+		}
+		rows = append(rows, row)
+	}
+
+	A := NewBitMatrix(Shape{len(rows), 0})
+	if len(rows) > 0 {
+		A = NewBitMatrix(Shape{len(rows), len(rows[0])})
+	}
+	for i, row := range rows {
+		for j, b := range row {
+			A.Set(i, j, b)
+		}
+	}
+	return A, nil
+}
+
+// BitFromMod 由 GF(2) 上的 ModMatrix 转换
+func BitFromMod(A ModMatrix) BitMatrix {
+	if A.P != 2 {
+		panic(fmt.Sprintf("BitFromMod(A): modulus must be 2, got %d.", A.P))
+	}
+	B := NewBitMatrix(A.Shape)
+	for i := 0; i < A.Row; i++ {
+		for j := 0; j < A.Col; j++ {
+			B.Set(i, j, A.Get(i, j) == 1)
+		}
+	}
+	return B
+}
+
+// Mod 转换为 GF(2) 上的 ModMatrix
+func (A BitMatrix) Mod() ModMatrix {
+	M := ModZeros(A.Shape, 2)
+	for i := 0; i < A.Row; i++ {
+		for j := 0; j < A.Col; j++ {
+			if A.Get(i, j) {
+				M.Set(i, j, 1)
+			}
+		}
+	}
+	return M
+}
+
+// Get 获取元素
+func (A BitMatrix) Get(i, j int) bool {
+	A.check(i, j)
+	return A.words[i*A.stride+j/64]>>(j%64)&1 == 1
+}
+
+// Set 设置元素
+func (A BitMatrix) Set(i, j int, v bool) {
+	A.check(i, j)
+	w := &A.words[i*A.stride+j/64]
+	if v {
+		*w |= 1 << (j % 64)
+	} else {
+		*w &^= 1 << (j % 64)
+	}
+}
+
+func (A BitMatrix) check(i, j int) {
+	if i >= A.Row || j >= A.Col || i < 0 || j < 0 {
+		panic(fmt.Sprintf("index out of bounds: (%d, %d) in %v", i, j, A.Shape))
+	}
+}
+
+func (A BitMatrix) row(i int) []uint64 {
+	return A.words[i*A.stride : (i+1)*A.stride]
+}
+
+// Copy 深拷贝
+func (A BitMatrix) Copy() BitMatrix {
+	S := A
+	S.words = append([]uint64(nil), A.words...)
+	return S
+}
+
+// Equal 判断两矩阵是否相等
+func (A BitMatrix) Equal(B BitMatrix) bool {
+	if ShapeNotEqual(A.Shape, B.Shape) {
+		return false
+	}
+	for i, w := range A.words {
+		if w != B.words[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// String 以 [1011; 0110] 形式输出
+func (A BitMatrix) String() string {
+	var sb strings.Builder
+	sb.WriteByte('[')
+	for i := 0; i < A.Row; i++ {
+		if i > 0 {
+			sb.WriteString("; ")
+		}
+		for j := 0; j < A.Col; j++ {
+			if A.Get(i, j) {
+				sb.WriteByte('1')
+			} else {
+				sb.WriteByte('0')
+			}
+		}
+	}
+	sb.WriteByte(']')
+	return sb.String()
+}
+
+// Weight 第 i 行中 1 的个数，即码字的汉明重量
+func (A BitMatrix) Weight(i int) int {
+	n := 0
+	for _, w := range A.row(i) {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+// Add 矩阵相加（异或）
+func (A BitMatrix) Add(B BitMatrix) BitMatrix {
+	if ShapeNotEqual(A.Shape, B.Shape) {
+		panic(fmt.Sprintf("two matrix cannot [add]. %v x %v", A.Shape, B.Shape))
+	}
+	S := A.Copy()
+	for i, w := range B.words {
+		S.words[i] ^= w
+	}
+	return S
+}
+
+// Dot 矩阵乘法，结果的第 i 行为 B 中与 A 第 i 行的 1 对应的各行之异或
+func (A BitMatrix) Dot(B BitMatrix) BitMatrix {
+	if A.Col != B.Row {
+		panic(fmt.Sprintf("two matrix cannot [dot]. %v x %v", A.Shape, B.Shape))
+	}
+	S := NewBitMatrix(Shape{A.Row, B.Col})
+	for i := 0; i < A.Row; i++ {
+		dst := S.row(i)
+		for k, w := range A.row(i) {
+			for ; w != 0; w &= w - 1 {
+				xorWords(dst, B.row(k*64+bits.TrailingZeros64(w)), 0)
+			}
+		}
+	}
+	return S
+}
+
+// T 转置
+func (A BitMatrix) T() BitMatrix {
+	S := NewBitMatrix(Shape{A.Col, A.Row})
+	for i := 0; i < A.Row; i++ {
+		for k, w := range A.row(i) {
+			for ; w != 0; w &= w - 1 {
+				S.Set(k*64+bits.TrailingZeros64(w), i, true)
+			}
+		}
+	}
+	return S
+}
+
+// Det 行列式，取值 0 或 1
+func (A BitMatrix) Det() uint64 {
+	if A.Row != A.Col {
+		panic("Det(): matrix must be square.")
+	}
+	if A.Rank() == A.Row {
+		return 1
+	}
+	return 0
+}
+
+// RREF 高斯-约当消元求行最简形，pivots 为各主元所在列
+func (A BitMatrix) RREF() (R BitMatrix, pivots []int) {
+	R = A.Copy()
+	pivots = R.eliminate(R.Col)
+	return
+}
+
+// Rank 秩
+func (A BitMatrix) Rank() int {
+	_, pivots := A.RREF()
+	return len(pivots)
+}
+
+// Inv 逆矩阵，A 奇异时以 ErrSingular panic
+func (A BitMatrix) Inv() BitMatrix {
+	if A.Row != A.Col {
+		panic("Inv(): matrix must be square.")
+	}
+	M := A.augment(BitEye(A.Row))
+	if len(M.eliminate(A.Col)) < A.Row {
+		panic(ErrSingular)
+	}
+	return M.columns(A.Col, M.Col)
+}
+
+// NullSpace 零空间的一组基，每列为一个基向量，形状为 Col x (Col - Rank)。
+// 对 k x n 的生成矩阵 G 调用时得到校验矩阵的转置 Hᵀ（n x (n - k)），即 H = G.NullSpace().T()
+func (A BitMatrix) NullSpace() BitMatrix {
+	R, pivots := A.RREF()
+	N := NewBitMatrix(Shape{A.Col, A.Col - len(pivots)})
+	for k, f := range freeColumns(A.Col, pivots) {
+		N.Set(f, k, true)
+		for i, c := range pivots {
+			if R.Get(i, f) {
+				N.Set(c, k, true)
+			}
+		}
+	}
+	return N
+}
+
+// Solve 求解线性方程组 AX = B，A 可以不是方阵。
+// 有多个解时自由变量取 0，无解时以 ErrNoSolution panic
+func (A BitMatrix) Solve(B BitMatrix) BitMatrix {
+	if A.Row != B.Row {
+		panic(fmt.Sprintf("Solve(B): shape not match. %v x %v", A.Shape, B.Shape))
+	}
+	M := A.augment(B)
+	pivots := M.eliminate(A.Col)
+	rhs := M.columns(A.Col, M.Col)
+	for i := len(pivots); i < M.Row; i++ {
+		for _, w := range rhs.row(i) {
+			if w != 0 {
+				panic(ErrNoSolution)
+			}
+		}
+	}
+	X := NewBitMatrix(Shape{A.Col, B.Col})
+	for i, c := range pivots {
+		copy(X.row(c), rhs.row(i))
+	}
+	return X
+}
+
+// augment 横向拼接 [A B]
+func (A BitMatrix) augment(B BitMatrix) BitMatrix {
+	M := NewBitMatrix(Shape{A.Row, A.Col + B.Col})
+	for i := 0; i < A.Row; i++ {
+		copy(M.row(i), A.row(i))
+		for k, w := range B.row(i) {
+			for ; w != 0; w &= w - 1 {
+				M.Set(i, A.Col+k*64+bits.TrailingZeros64(w), true)
+			}
+		}
+	}
+	return M
+}
+
+// columns 取 [from, to) 列
+func (A BitMatrix) columns(from, to int) BitMatrix {
+	S := NewBitMatrix(Shape{A.Row, to - from})
+	for i := 0; i < A.Row; i++ {
+		for j := from; j < to; j++ {
+			if A.Get(i, j) {
+				S.Set(i, j-from, true)
+			}
+		}
+	}
+	return S
+}
+
+// eliminate 原地高斯-约当消元，只在前 ncols 列中选主元，返回主元所在列
+func (A BitMatrix) eliminate(ncols int) (pivots []int) {
+	r := 0
+	for j := 0; j < ncols && r < A.Row; j++ {
+		word, bit := j/64, uint64(1)<<(j%64)
+		p := r
+		for p < A.Row && A.words[p*A.stride+word]&bit == 0 {
+			p++
+		}
+		if p == A.Row {
+			continue
+		}
+		if p != r {
+			rp, rr := A.row(p), A.row(r)
+			for k := range rr {
+				rp[k], rr[k] = rr[k], rp[k]
+			}
+		}
+		// 主元行在 word 之前的位都已为 0，只需异或其后的字
+		pivot := A.row(r)
+		for i := 0; i < A.Row; i++ {
+			if i != r && A.words[i*A.stride+word]&bit != 0 {
+				xorWords(A.row(i), pivot, word)
+			}
+		}
+		pivots = append(pivots, j)
+		r++
+	}
+	return
+}
+
+// xorWords dst ^= src，从第 from 个字开始
+func xorWords(dst, src []uint64, from int) {
+	for k := from; k < len(dst); k++ {
+		dst[k] ^= src[k]
+	}
+}
@@ -0,0 +1,135 @@
+package matrix
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func randBits(r *rand.Rand, shape Shape) BitMatrix {
+	A := NewBitMatrix(shape)
+	for i := range A.words {
+		A.words[i] = r.Uint64()
+	}
+	// 清除每行末尾多余的位
+	if rem := shape.Col % 64; rem != 0 {
+		for i := 0; i < A.Row; i++ {
+			A.words[(i+1)*A.stride-1] &= 1<<rem - 1
+		}
+	}
+	return A
+}
+
+func TestBitMatrixHamming(t *testing.T) {
+	// Hamming(7,4)：G = [I | P]，H = [P' | I]
+	G, err := ParseBitMatrix("[1000110; 0100101; 0010011; 0001111]")
+	if err != nil {
+		t.Fatal(err)
+	}
+	H, _ := ParseBitMatrix(`
+		1101100
+		1011010
+		0111001`)
+	if !G.Dot(H.T()).Equal(NewBitMatrix(Shape{4, 3})) {
+		t.Errorf("G H' = %v", G.Dot(H.T()))
+	}
+	if G.Rank() != 4 || H.Rank() != 3 {
+		t.Errorf("rank G = %d, rank H = %d", G.Rank(), H.Rank())
+	}
+
+	// H 的零空间即码空间
+	N := H.NullSpace()
+	if N.Shape != (Shape{7, 4}) || !H.Dot(N).Equal(NewBitMatrix(Shape{3, 4})) {
+		t.Errorf("NullSpace(H) = %v", N)
+	}
+	for i := 0; i < G.Row; i++ {
+		if G.Weight(i) < 3 {
+			t.Errorf("codeword %d has weight %d", i, G.Weight(i))
+		}
+	}
+
+	// 单个错误位的伴随式等于 H 的对应列
+	received := G.Copy()
+	received.Set(2, 5, !received.Get(2, 5))
+	syndrome := H.Dot(received.T())
+	if got := syndrome.T().String(); got != "[000; 000; 010; 000]" {
+		t.Errorf("syndrome = %s", got)
+	}
+	if s := G.String(); s != "[1000110; 0100101; 0010011; 0001111]" {
+		t.Errorf("String() = %s", s)
+	}
+
+	if _, err := ParseBitMatrix("101; 12"); err == nil {
+		t.Error("ParseBitMatrix accepted invalid bit")
+	}
+	if _, err := ParseBitMatrix("101; 10"); err == nil {
+		t.Error("ParseBitMatrix accepted ragged rows")
+	}
+}
+
+func TestBitMatrixMatchesMod(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, shape := range []Shape{{5, 5}, {20, 70}, {70, 20}, {65, 130}} {
+		A := randBits(r, shape)
+		M := A.Mod()
+		if !BitFromMod(M).Equal(A) {
+			t.Fatalf("%v: conversion round trip", shape)
+		}
+		R, pivots := A.RREF()
+		MR, mpivots := M.RREF()
+		if !BitFromMod(MR).Equal(R) || len(pivots) != len(mpivots) {
+			t.Errorf("%v: RREF differs from GF(2) ModMatrix", shape)
+		}
+
+		B := randBits(r, Shape{shape.Col, 9})
+		if !BitFromMod(M.Dot(B.Mod())).Equal(A.Dot(B)) {
+			t.Errorf("%v: Dot differs from GF(2) ModMatrix", shape)
+		}
+		if !A.T().T().Equal(A) || !BitFromMod(M.T()).Equal(A.T()) {
+			t.Errorf("%v: T", shape)
+		}
+
+		N := A.NullSpace()
+		if !A.Dot(N).Equal(NewBitMatrix(Shape{A.Row, N.Col})) || N.Col != shape.Col-len(pivots) {
+			t.Errorf("%v: NullSpace", shape)
+		}
+
+		X0 := randBits(r, Shape{shape.Col, 2})
+		Y := A.Dot(X0)
+		if X := A.Solve(Y); !A.Dot(X).Equal(Y) {
+			t.Errorf("%v: Solve", shape)
+		}
+	}
+}
+
+func TestBitMatrixInv(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	n := 0
+	for trial := 0; trial < 20; trial++ {
+		A := randBits(r, Shape{100, 100})
+		if A.Det() == 0 {
+			expectPanic(t, "singular", ErrSingular, func() { A.Inv() })
+			continue
+		}
+		n++
+		if !A.Dot(A.Inv()).Equal(BitEye(100)) {
+			t.Fatal("A * Inv(A) != I")
+		}
+	}
+	if n == 0 {
+		t.Error("no invertible matrix among 20 random samples")
+	}
+}
+
+func TestBitMatrixLarge(t *testing.T) {
+	// 数千列的生成矩阵应能很快消元
+	r := rand.New(rand.NewSource(3))
+	A := randBits(r, Shape{500, 4000})
+	start := time.Now()
+	if rank := A.Rank(); rank != 500 {
+		t.Errorf("rank = %d, want 500", rank)
+	}
+	if d := time.Since(start); d > 2*time.Second {
+		t.Errorf("Rank of 500x4000 took %v", d)
+	}
+}
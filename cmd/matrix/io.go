@@ -0,0 +1,163 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mrfyo/matrix"
+)
+
+var (
+	inputFormats  = []string{"auto", "csv", "json", "matlab"}
+	outputFormats = []string{"matlab", "pretty", "csv", "json", "latex", "markdown"}
+)
+
+// result 命令的一个输出，scalar 为 true 时 value 是 1x1 矩阵
+type result struct {
+	name   string
+	value  matrix.Matrix
+	scalar bool
+}
+
+func readFile(file, format string, stdin io.Reader) (matrix.Matrix, error) {
+	var data []byte
+	var err error
+	if file == "-" {
+		data, err = io.ReadAll(stdin)
+		file = "<stdin>"
+	} else {
+		data, err = os.ReadFile(file)
+	}
+	if err != nil {
+		return matrix.Matrix{}, errorf(exitInput, "%v", err)
+	}
+
+	A, err := decode(data, format)
+	if err != nil {
+		return matrix.Matrix{}, errorf(exitInput, "%s: %v", file, err)
+	}
+	return A, nil
+}
+
+// decode 按格式解析矩阵，auto 时依据内容判断：JSON 以 { 或 [[ 开头，其余先按 Matlab 字面量、再按 CSV 解析
+func decode(data []byte, format string) (A matrix.Matrix, err error) {
+	if format == "auto" {
+		t := bytes.TrimSpace(data)
+		switch {
+		case bytes.HasPrefix(t, []byte("{")) ||
+			(bytes.HasPrefix(t, []byte("[")) && bytes.HasPrefix(bytes.TrimSpace(t[1:]), []byte("["))):
+			format = "json"
+		default:
+			if A, err = matrix.Parse(string(data)); err == nil {
+				return A, nil
+			}
+			if B, _, cerr := matrix.ReadCSV(bytes.NewReader(data), &matrix.CSVOptions{Comment: '#'}); cerr == nil {
+				return B, nil
+			}
+			return A, err
+		}
+	}
+
+	switch format {
+	case "json":
+		err = json.Unmarshal(data, &A)
+	case "csv":
+		A, _, err = matrix.ReadCSV(bytes.NewReader(data), &matrix.CSVOptions{Comment: '#'})
+	case "matlab":
+		A, err = matrix.Parse(string(data))
+	}
+	return
+}
+
+func writeResults(w io.Writer, results []result, format string, prec int) error {
+	// prec 为 0 表示最短表示
+	var precision *int
+	if prec > 0 {
+		precision = &prec
+	}
+	opts := &matrix.ExportOptions{Precision: precision}
+	number := func(v float64) string {
+		if prec > 0 {
+			return strconv.FormatFloat(v, 'g', prec, 64)
+		}
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+
+	if format == "json" {
+		values := make(map[string]interface{}, len(results))
+		for _, r := range results {
+			if r.scalar {
+				values[r.name] = r.value.GetIndex(0)
+			} else {
+				values[r.name] = r.value
+			}
+		}
+		var v interface{} = values
+		if len(results) == 1 {
+			v = values[results[0].name]
+		}
+		b, err := json.Marshal(v)
+		if err != nil {
+			return errorf(exitNumeric, "%v", err)
+		}
+		_, err = fmt.Fprintf(w, "%s\n", b)
+		return err
+	}
+
+	var sb strings.Builder
+	for i, r := range results {
+		if i > 0 && format != "matlab" {
+			sb.WriteString("\n")
+		}
+		multi := len(results) > 1
+
+		if r.scalar {
+			if multi {
+				fmt.Fprintf(&sb, "%s = ", r.name)
+			}
+			sb.WriteString(number(r.value.GetIndex(0)) + "\n")
+			continue
+		}
+
+		switch format {
+		case "matlab":
+			if multi {
+				fmt.Fprintf(&sb, "%s = ", r.name)
+			}
+			sb.WriteString(matrix.Matlab(r.value, opts) + "\n")
+		case "pretty":
+			if multi {
+				fmt.Fprintf(&sb, "%s =\n", r.name)
+			}
+			if prec > 0 {
+				fmt.Fprintf(&sb, "%+.*v\n", prec, r.value)
+			} else {
+				fmt.Fprintf(&sb, "%+v\n", r.value)
+			}
+		case "csv":
+			if multi {
+				fmt.Fprintf(&sb, "# %s\n", r.name)
+			}
+			if err := matrix.WriteCSV(&sb, r.value, &matrix.CSVOptions{Precision: precision}); err != nil {
+				return err
+			}
+		case "latex":
+			if multi {
+				fmt.Fprintf(&sb, "%s = ", r.name)
+			}
+			sb.WriteString(matrix.LaTeX(r.value, opts) + "\n")
+		case "markdown":
+			if multi {
+				fmt.Fprintf(&sb, "**%s**\n\n", r.name)
+			}
+			sb.WriteString(matrix.Markdown(r.value, opts) + "\n")
+		}
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
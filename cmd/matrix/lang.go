@@ -0,0 +1,199 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mrfyo/matrix"
+	"github.com/mrfyo/matrix/expr"
+)
+
+// replFunctions 在编译表达式前求值的函数。它们返回多个值或结果形状依赖数据，无法在编译期确定形状。
+// 作为整个右端时返回全部结果，出现在表达式内部时取第一个结果
+var replFunctions = map[string]func(A matrix.Matrix) ([]matrix.Matrix, error){
+	"qr": func(A matrix.Matrix) ([]matrix.Matrix, error) {
+		if err := squareArg("qr", A); err != nil {
+			return nil, err
+		}
+		Q, R := matrix.QR(A)
+		return []matrix.Matrix{Q, R}, nil
+	},
+	"lu": func(A matrix.Matrix) ([]matrix.Matrix, error) {
+		if err := squareArg("lu", A); err != nil {
+			return nil, err
+		}
+		U, L := matrix.LU(A)
+		return []matrix.Matrix{L, U}, nil
+	},
+	"roots": func(P matrix.Matrix) ([]matrix.Matrix, error) {
+		if !matrix.IsVector(P) || P.Size() < 2 {
+			return nil, fmt.Errorf("roots: polynomial must be a vector with at least 2 coefficients, got %v", P.Shape)
+		}
+		return []matrix.Matrix{matrix.Root(P)}, nil
+	},
+	"eig": func(A matrix.Matrix) ([]matrix.Matrix, error) {
+		if err := squareArg("eig", A); err != nil {
+			return nil, err
+		}
+		if !matrix.MatrixEqual(A, A.T()) {
+			return nil, fmt.Errorf("eig: matrix must be symmetric")
+		}
+		D, V := matrix.EigSym(A)
+		return []matrix.Matrix{D.T(), V}, nil
+	},
+}
+
+func squareArg(name string, A matrix.Matrix) error {
+	if A.Row != A.Col || A.Size() == 0 {
+		return fmt.Errorf("%s: matrix must be square, got %v", name, A.Shape)
+	}
+	return nil
+}
+
+// evaluate 求值语句右端，函数调用可能返回多个值
+func evaluate(src string, vars map[string]matrix.Matrix) ([]matrix.Matrix, error) {
+	if name, arg, offset, ok := splitCall(src); ok {
+		if _, isREPL := replFunctions[name]; isREPL {
+			return callREPL(name, arg, offset, vars)
+		}
+	}
+
+	src, env, err := bindCalls(src, vars)
+	if err != nil {
+		return nil, err
+	}
+	R, err := expr.Eval(src, env)
+	if err != nil {
+		return nil, err
+	}
+	return []matrix.Matrix{R}, nil
+}
+
+// callREPL 求值 REPL 函数，offset 为参数在语句中的偏移
+func callREPL(name, arg string, offset int, vars map[string]matrix.Matrix) (out []matrix.Matrix, err error) {
+	args, err := evaluate(arg, vars)
+	if err != nil {
+		var e *expr.Error
+		if errors.As(err, &e) {
+			e.Pos += offset
+		}
+		return nil, err
+	}
+
+	defer func() {
+		// 库函数以 panic 报告错误，这里转换为普通错误
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%s: %v", name, r)
+		}
+	}()
+	return replFunctions[name](args[0])
+}
+
+// bindCalls 先求值表达式内部的 REPL 函数调用，将第一个结果绑定到临时变量，
+// 并在 src 中以等长的临时变量名替换调用，使报告的错误位置不变
+func bindCalls(src string, vars map[string]matrix.Matrix) (string, map[string]matrix.Matrix, error) {
+	env, copied := vars, false
+	b := []byte(src)
+	tmp := 0
+	for i := 0; i < len(b); i++ {
+		if !isIdentStart(b[i]) || (i > 0 && isIdentChar(b[i-1])) {
+			continue
+		}
+		j := i
+		for j < len(b) && isIdentChar(b[j]) {
+			j++
+		}
+		name := string(b[i:j])
+		open := j
+		for open < len(b) && (b[open] == ' ' || b[open] == '\t') {
+			open++
+		}
+		if _, ok := replFunctions[name]; !ok || open == len(b) || b[open] != '(' {
+			i = j - 1
+			continue
+		}
+		close := matchParen(b, open)
+		if close < 0 {
+			// 括号不匹配，交给 expr 报告语法错误
+			break
+		}
+
+		out, err := callREPL(name, string(b[open+1:close]), open+1, vars)
+		if err != nil {
+			return "", nil, err
+		}
+		var t string
+		for {
+			tmp++
+			t = fmt.Sprintf("_%d", tmp)
+			if _, used := vars[t]; !used {
+				break
+			}
+		}
+		if !copied {
+			copied = true
+			env = make(map[string]matrix.Matrix, len(vars)+1)
+			for k, v := range vars {
+				env[k] = v
+			}
+		}
+		env[t] = out[0]
+
+		width := close + 1 - i
+		if len(t) < width {
+			t += strings.Repeat(" ", width-len(t))
+		}
+		b = append(b[:i], append([]byte(t), b[close+1:]...)...)
+		i += len(t) - 1
+	}
+	return string(b), env, nil
+}
+
+// matchParen 返回与 b[open] 处左括号匹配的右括号位置，不存在时返回 -1
+func matchParen(b []byte, open int) int {
+	depth := 0
+	for k := open; k < len(b); k++ {
+		switch b[k] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return k
+			}
+		}
+	}
+	return -1
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentChar(c byte) bool {
+	return isIdentStart(c) || (c >= '0' && c <= '9')
+}
+
+// splitCall 当 src 整体为 name(arg) 时拆分出函数名与参数，offset 为参数在 src 中的偏移
+func splitCall(src string) (name, arg string, offset int, ok bool) {
+	open := strings.IndexByte(src, '(')
+	t := strings.TrimRight(src, " \t")
+	if open < 0 || !strings.HasSuffix(t, ")") {
+		return "", "", 0, false
+	}
+	name = strings.TrimSpace(src[:open])
+	depth := 0
+	for i := open; i < len(t); i++ {
+		switch t[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 && i != len(t)-1 {
+				return "", "", 0, false
+			}
+		}
+	}
+	return name, t[open+1 : len(t)-1], open + 1, true
+}
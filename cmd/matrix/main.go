@@ -0,0 +1,193 @@
+// Command matrix 是 github.com/mrfyo/matrix 的命令行矩阵计算器。
+//
+// 用法：
+//
+//	matrix [flags] <command> [file ...]
+//
+// 矩阵从文件读取，文件名为 - 或省略时读取标准输入，输入可以是 CSV、JSON 或 Matlab 字面量。
+// flag 可以出现在命令与文件名之间或之后，-- 之后的参数都视为文件名。
+// 命令：
+//
+//	det A          行列式
+//	inv A          逆矩阵
+//	lu A           LU 分解（不选主元）
+//	qr A           QR 分解
+//	chol A         Cholesky 分解
+//	transpose A    转置
+//	roots P        多项式求根，P 为降幂系数向量
+//	mul A B        矩阵乘法
+//	solve A B      求解 AX = B
+//	repl           交互式会话，支持变量赋值、运算符与函数调用，输入 help 查看语法
+//
+// 退出码：0 成功，1 用法错误，2 输入错误，3 形状不匹配，4 奇异矩阵，5 其他数值错误。
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// 退出码
+const (
+	exitOK = iota
+	exitUsage
+	exitInput
+	exitShape
+	exitSingular
+	exitNumeric
+)
+
+// cliError 带退出码的错误
+type cliError struct {
+	code int
+	err  error
+}
+
+func (e *cliError) Error() string {
+	return e.err.Error()
+}
+
+func errorf(code int, format string, args ...interface{}) error {
+	return &cliError{code: code, err: fmt.Errorf(format, args...)}
+}
+
+const usage = `usage: matrix [flags] <command> [file ...]
+
+commands:
+  det A          determinant
+  inv A          inverse
+  lu A           LU decomposition without pivoting
+  qr A           QR decomposition
+  chol A         Cholesky decomposition
+  transpose A    transpose
+  roots P        roots of polynomial with descending coefficients P
+  mul A B        matrix product
+  solve A B      solve A X = B
+  repl           interactive session, type help for syntax
+
+files default to stdin; "-" also reads stdin.
+flags may appear anywhere; arguments after "--" are file names.
+
+flags:
+`
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("matrix", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	in := fs.String("f", "auto", "input format: auto, csv, json, matlab")
+	out := fs.String("o", "matlab", "output format: matlab, pretty, csv, json, latex, markdown")
+	prec := fs.Int("p", 0, "output precision, 0 for shortest representation")
+	fs.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		fs.PrintDefaults()
+	}
+
+	pos, err := parseInterspersed(fs, args)
+	if err != nil {
+		return exitUsage
+	}
+	if len(pos) == 0 {
+		fs.Usage()
+		return exitUsage
+	}
+	name, files := pos[0], pos[1:]
+
+	if name == "repl" {
+		if len(files) > 0 {
+			fmt.Fprintln(stderr, "matrix: repl takes no arguments")
+			return exitUsage
+		}
+		return runREPL(stdin, stdout, stderr, *prec)
+	}
+
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(stderr, "matrix: unknown command %q\n", name)
+		fs.Usage()
+		return exitUsage
+	}
+	if !validFormat(*in, inputFormats) || !validFormat(*out, outputFormats) {
+		fmt.Fprintf(stderr, "matrix: unknown format, input must be one of %s and output one of %s\n",
+			strings.Join(inputFormats, ", "), strings.Join(outputFormats, ", "))
+		return exitUsage
+	}
+
+	if len(files) > cmd.arity || (len(files) < cmd.arity && cmd.arity > 1) {
+		fmt.Fprintf(stderr, "matrix: %s takes %d matrix argument(s), got %d\n", name, cmd.arity, len(files))
+		return exitUsage
+	}
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+
+	err = func() error {
+		operands := make([]operand, len(files))
+		stdinUsed := false
+		for i, file := range files {
+			if file == "-" {
+				if stdinUsed {
+					return errorf(exitUsage, "stdin can only be read once")
+				}
+				stdinUsed = true
+			}
+			A, err := readFile(file, *in, stdin)
+			if err != nil {
+				return err
+			}
+			operands[i] = operand{name: file, value: A}
+		}
+
+		results, err := call(cmd, operands)
+		if err != nil {
+			return err
+		}
+		return writeResults(stdout, results, *out, *prec)
+	}()
+
+	if err != nil {
+		fmt.Fprintf(stderr, "matrix: %v\n", err)
+		var ce *cliError
+		if errors.As(err, &ce) {
+			return ce.code
+		}
+		return exitInput
+	}
+	return exitOK
+}
+
+// parseInterspersed 解析参数，允许 flag 出现在命令与文件名之间或之后，返回其余的位置参数。
+// -- 之后的参数都视为位置参数
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var pos []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		rest := fs.Args()
+		if k := len(args) - len(rest); k > 0 && args[k-1] == "--" {
+			return append(pos, rest...), nil
+		}
+		if len(rest) == 0 {
+			return pos, nil
+		}
+		pos = append(pos, rest[0])
+		args = rest[1:]
+	}
+}
+
+func validFormat(f string, formats []string) bool {
+	for _, v := range formats {
+		if f == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func runCLI(t *testing.T, stdin string, args ...string) (code int, stdout, stderr string) {
+	t.Helper()
+	var out, errOut bytes.Buffer
+	code = run(args, strings.NewReader(stdin), &out, &errOut)
+	return code, out.String(), errOut.String()
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a.csv")
+	b := filepath.Join(dir, "b.json")
+	os.WriteFile(a, []byte("2,1\n1,3\n"), 0o644)
+	os.WriteFile(b, []byte(`[[3],[5]]`), 0o644)
+
+	cases := []struct {
+		stdin  string
+		args   []string
+		code   int
+		stdout string
+	}{
+		{"[1 2; 3 4]", []string{"det"}, exitOK, "-2\n"},
+		{"[2 0; 0 4]", []string{"inv"}, exitOK, "[0.5 0; 0 0.25]\n"},
+		{"[1 2; 3 4]", []string{"-o", "json", "transpose", "-"}, exitOK, "[[1,3],[2,4]]\n"},
+		{"[4 12; 12 37]", []string{"chol", "-o", "csv"}, exitOK, "2,0\n6,1\n"},
+		{"[1 -3 2]", []string{"roots"}, exitOK, "[1 2]\n"},
+		{"", []string{"solve", a, b}, exitOK, "[0.8; 1.4]\n"},
+		{"", []string{"transpose", a, "-o", "json"}, exitOK, "[[2,1],[1,3]]\n"},
+		{"", []string{"solve", a, "-o", "csv", b}, exitOK, "0.8\n1.4\n"},
+		{"", []string{"transpose", "--", "-o"}, exitInput, ""},
+		{"", []string{"transpose", a, "-x"}, exitUsage, ""},
+		{"[1 2 3]", []string{"mul", "-", b}, exitShape, ""},
+		{"[1 2 3]", []string{"inv"}, exitShape, ""},
+		{"[1 2; 2 4]", []string{"inv"}, exitSingular, ""},
+		{"[1 2; 2 4]", []string{"solve", "-", b}, exitSingular, ""},
+		{"[1 2; 3 x]", []string{"det"}, exitInput, ""},
+		{"[1 2; 2 -4]", []string{"chol"}, exitNumeric, ""},
+		{"", []string{"frobnicate"}, exitUsage, ""},
+		{"", []string{"mul", a}, exitUsage, ""},
+	}
+	for _, c := range cases {
+		code, stdout, stderr := runCLI(t, c.stdin, c.args...)
+		if code != c.code || (c.code == exitOK && stdout != c.stdout) {
+			t.Errorf("matrix %v: exit %d, stdout %q, stderr %q", c.args, code, stdout, stderr)
+		}
+	}
+}
+
+func TestRunMultipleResults(t *testing.T) {
+	code, stdout, _ := runCLI(t, "[1 2; 3 4]", "lu")
+	if code != exitOK || stdout != "L = [1 0; 3 1]\nU = [1 2; 0 -2]\n" {
+		t.Errorf("matrix lu: exit %d, stdout %q", code, stdout)
+	}
+
+	code, stdout, _ = runCLI(t, "[1 2; 3 4]", "-o", "json", "lu")
+	if code != exitOK || stdout != `{"L":[[1,0],[3,1]],"U":[[1,2],[0,-2]]}`+"\n" {
+		t.Errorf("matrix -o json lu: exit %d, stdout %q", code, stdout)
+	}
+}
+
+func TestREPL(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "ws.json")
+	script := strings.Join([]string{
+		"A = [2 1;",
+		"1 3];",
+		"b = [3; 5];",
+		"x = A \\ b",
+		"det(A')",
+		"[Q, R] = qr(A);",
+		"p = [1 -3 2];",
+		"r = roots(p)'",
+		"B2 = qr(A)*2",
+		"D = 1 + roots(p .* [1 1 1])'",
+		"n = 2 * roots(roots([1 -3 2]) .* [1 -1])",
+		"C = A .* A - 2*A",
+		"inv([1 2; 2 4])",
+		"A * [1 2 3]",
+		"det(B)",
+		"save " + file,
+		"clear",
+		"load " + file,
+		"who",
+		"!3",
+		"history",
+		"exit",
+		"det(A)",
+	}, "\n")
+
+	code, stdout, stderr := runCLI(t, script, "repl")
+	if code != exitOK {
+		t.Fatalf("matrix repl: exit %d, stderr %q", code, stderr)
+	}
+	for _, want := range []string{
+		"x =\n[ 0.800000\n  1.400000 ]",
+		"ans = 5\n",
+		"C =\n[  0  -1\n  -1   3 ]",
+		"  A          (2, 2)\n",
+		"  R          (2, 2)\n",
+		"   3  x = A \\ b\n",
+		"r =\n[ 1\n  2 ]",
+		"B2 =\n[ 1.788854  -0.894427\n  0.894427   1.788854 ]",
+		"D =\n[ 2\n  3 ]",
+		"n = 4\n",
+	} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("matrix repl: stdout missing %q:\n%s", want, stdout)
+		}
+	}
+	if strings.Count(stdout, "x =\n") != 2 {
+		t.Errorf("matrix repl: !3 was not repeated:\n%s", stdout)
+	}
+	for _, want := range []string{"singular", "inner dimensions mismatch", `undefined variable "B"`} {
+		if !strings.Contains(stderr, want) {
+			t.Errorf("matrix repl: stderr missing %q:\n%s", want, stderr)
+		}
+	}
+	if strings.Count(stderr, "error:") != 3 {
+		t.Errorf("matrix repl: unexpected errors:\n%s", stderr)
+	}
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"errors"
+	"math"
+
+	"github.com/mrfyo/matrix"
+)
+
+// operand 命令的一个输入矩阵，name 为来源文件名
+type operand struct {
+	name  string
+	value matrix.Matrix
+}
+
+type command struct {
+	arity int
+	run   func(args []operand) ([]result, error)
+}
+
+var commands = map[string]command{
+	"det": {1, func(args []operand) ([]result, error) {
+		A := args[0]
+		if err := square(A); err != nil {
+			return nil, err
+		}
+		return []result{scalar("det", matrix.Det(A.value))}, nil
+	}},
+	"inv": {1, func(args []operand) ([]result, error) {
+		A := args[0]
+		if err := square(A); err != nil {
+			return nil, err
+		}
+		// 列主元消元，比 matrix.Inv 更稳定
+		return []result{{name: "inv", value: matrix.Solve(A.value, matrix.Eye(A.value.Row))}}, nil
+	}},
+	"lu": {1, func(args []operand) ([]result, error) {
+		A := args[0]
+		if err := square(A); err != nil {
+			return nil, err
+		}
+		U, L := matrix.LU(A.value)
+		if !finite(U) || !finite(L) {
+			return nil, errorf(exitSingular, "%s: zero pivot in LU decomposition without pivoting", A.name)
+		}
+		return []result{{name: "L", value: L}, {name: "U", value: U}}, nil
+	}},
+	"qr": {1, func(args []operand) ([]result, error) {
+		A := args[0]
+		if err := square(A); err != nil {
+			return nil, err
+		}
+		Q, R := matrix.QR(A.value)
+		if !finite(Q) || !finite(R) {
+			return nil, errorf(exitSingular, "%s: matrix has linearly dependent columns", A.name)
+		}
+		return []result{{name: "Q", value: Q}, {name: "R", value: R}}, nil
+	}},
+	"chol": {1, func(args []operand) ([]result, error) {
+		A := args[0]
+		if err := square(A); err != nil {
+			return nil, err
+		}
+		if !matrix.MatrixEqual(A.value, A.value.T()) {
+			return nil, errorf(exitNumeric, "%s: matrix is not symmetric", A.name)
+		}
+		L, _ := matrix.Cholesky(A.value)
+		if !finite(L) {
+			return nil, errorf(exitNumeric, "%s: matrix is not positive definite", A.name)
+		}
+		return []result{{name: "L", value: L}}, nil
+	}},
+	"transpose": {1, func(args []operand) ([]result, error) {
+		return []result{{name: "T", value: args[0].value.T()}}, nil
+	}},
+	"roots": {1, func(args []operand) ([]result, error) {
+		P := args[0]
+		if !matrix.IsVector(P.value) || P.value.Size() < 2 {
+			return nil, errorf(exitShape, "%s: polynomial must be a vector with at least 2 coefficients, got %v", P.name, P.value.Shape)
+		}
+		return []result{{name: "roots", value: matrix.Root(P.value)}}, nil
+	}},
+	"mul": {2, func(args []operand) ([]result, error) {
+		A, B := args[0], args[1]
+		if A.value.Col != B.value.Row {
+			return nil, errorf(exitShape, "cannot multiply %v by %v", A.value.Shape, B.value.Shape)
+		}
+		return []result{{name: "C", value: A.value.Dot(B.value)}}, nil
+	}},
+	"solve": {2, func(args []operand) ([]result, error) {
+		A, B := args[0], args[1]
+		if err := square(A); err != nil {
+			return nil, err
+		}
+		if A.value.Row != B.value.Row {
+			return nil, errorf(exitShape, "cannot solve %v system with right-hand side %v", A.value.Shape, B.value.Shape)
+		}
+		return []result{{name: "X", value: matrix.Solve(A.value, B.value)}}, nil
+	}},
+}
+
+// call 执行命令，并将库中的 panic 转换为对应退出码的错误
+func call(cmd command, args []operand) (results []result, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if e, ok := r.(error); ok && errors.Is(e, matrix.ErrSingular) {
+				err = errorf(exitSingular, "%v", e)
+				return
+			}
+			err = errorf(exitNumeric, "%v", r)
+		}
+	}()
+	return cmd.run(args)
+}
+
+func scalar(name string, v float64) result {
+	return result{name: name, value: matrix.NewVector([]float64{v}, 1), scalar: true}
+}
+
+func square(A operand) error {
+	if A.value.Row != A.value.Col || A.value.Size() == 0 {
+		return errorf(exitShape, "%s: matrix must be square and non-empty, got %v", A.name, A.value.Shape)
+	}
+	return nil
+}
+
+func finite(A matrix.Matrix) bool {
+	for i := 0; i < A.Size(); i++ {
+		if v := A.GetIndex(i); math.IsNaN(v) || math.IsInf(v, 0) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mrfyo/matrix"
+	"github.com/mrfyo/matrix/expr"
+)
+
+const replHelp = `statements:
+  A = [1 2; 3 4]       assign, a trailing ; suppresses output
+  [Q, R] = qr(A)       assign multiple outputs
+  A' * A \ b           expression, result is stored in ans
+operators:
+  + - * .* / \ '       add, subtract, product, element-wise product, divide, solve, transpose
+functions:
+  %s
+  qr, lu, eig and roots may return several results, e.g. [Q, R] = qr(A);
+  inside a larger expression only the first result is used, e.g. qr(A) * 2
+commands:
+  who                  list variables
+  clear [name ...]     remove variables, all when no name given
+  save [file]          save workspace as JSON, default workspace.json
+  load [file]          load workspace from JSON
+  history              list previous statements, !n repeats statement n
+  help                 show this message
+  exit, quit           leave
+`
+
+var (
+	assignRe = regexp.MustCompile(`(?s)^([A-Za-z_][A-Za-z0-9_]*)\s*=([^=].*)$`)
+	multiRe  = regexp.MustCompile(`(?s)^\[\s*([A-Za-z_][A-Za-z0-9_]*(?:\s*,\s*[A-Za-z_][A-Za-z0-9_]*)*)\s*\]\s*=([^=].*)$`)
+)
+
+// session REPL 的工作区与历史记录
+type session struct {
+	vars    map[string]matrix.Matrix
+	history []string
+	prec    int
+	out     io.Writer
+}
+
+func runREPL(stdin io.Reader, stdout, stderr io.Writer, prec int) int {
+	s := &session{vars: map[string]matrix.Matrix{}, prec: prec, out: stdout}
+	sc := bufio.NewScanner(stdin)
+	for {
+		fmt.Fprint(stdout, ">> ")
+		line, ok := readStatement(sc, stdout)
+		if !ok {
+			fmt.Fprintln(stdout)
+			break
+		}
+		quit, err := s.exec(line)
+		if err != nil {
+			fmt.Fprintf(stderr, "error: %v\n", err)
+		}
+		if quit {
+			break
+		}
+	}
+	if err := sc.Err(); err != nil {
+		fmt.Fprintf(stderr, "matrix: %v\n", err)
+		return exitInput
+	}
+	return exitOK
+}
+
+// readStatement 读取一条语句，方括号未闭合时继续读取下一行
+func readStatement(sc *bufio.Scanner, prompt io.Writer) (string, bool) {
+	var lines []string
+	for sc.Scan() {
+		lines = append(lines, sc.Text())
+		text := strings.Join(lines, "\n")
+		if strings.Count(text, "[") <= strings.Count(text, "]") {
+			return text, true
+		}
+		fmt.Fprint(prompt, ".. ")
+	}
+	if len(lines) > 0 {
+		return strings.Join(lines, "\n"), true
+	}
+	return "", false
+}
+
+// exec 执行一条语句，quit 为 true 时结束会话
+func (s *session) exec(line string) (quit bool, err error) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "%") {
+		return false, nil
+	}
+
+	if strings.HasPrefix(line, "!") {
+		n, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+		if err != nil || n < 1 || n > len(s.history) {
+			return false, fmt.Errorf("no statement %s in history", line)
+		}
+		line = s.history[n-1]
+		fmt.Fprintln(s.out, line)
+	}
+
+	// 形如 who = 1 的赋值不视为命令
+	fields := strings.Fields(line)
+	cmd := fields[0]
+	if assignRe.MatchString(line) {
+		cmd = ""
+	}
+	switch cmd {
+	case "exit", "quit":
+		return true, nil
+	case "history":
+		for i, h := range s.history {
+			fmt.Fprintf(s.out, "%4d  %s\n", i+1, h)
+		}
+		return false, nil
+	}
+	s.history = append(s.history, line)
+
+	switch cmd {
+	case "help":
+		names := expr.Functions()
+		for name := range replFunctions {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fmt.Fprintf(s.out, replHelp, strings.Join(names, ", "))
+		return false, nil
+	case "who":
+		s.who()
+		return false, nil
+	case "clear":
+		if len(fields) == 1 {
+			s.vars = map[string]matrix.Matrix{}
+		}
+		for _, name := range fields[1:] {
+			delete(s.vars, name)
+		}
+		return false, nil
+	case "save", "load":
+		if len(fields) > 2 {
+			return false, fmt.Errorf("usage: %s [file]", fields[0])
+		}
+		file := "workspace.json"
+		if len(fields) == 2 {
+			file = fields[1]
+		}
+		if fields[0] == "save" {
+			return false, s.save(file)
+		}
+		return false, s.load(file)
+	}
+
+	silent := strings.HasSuffix(line, ";")
+	line = strings.TrimSuffix(line, ";")
+
+	names, src := []string{"ans"}, line
+	if m := multiRe.FindStringSubmatch(line); m != nil {
+		names = strings.Split(m[1], ",")
+		for i := range names {
+			names[i] = strings.TrimSpace(names[i])
+		}
+		src = m[2]
+	} else if m := assignRe.FindStringSubmatch(line); m != nil {
+		names, src = []string{m[1]}, m[2]
+	}
+
+	values, err := evaluate(src, s.vars)
+	if err != nil {
+		return false, err
+	}
+	if len(names) > len(values) {
+		return false, fmt.Errorf("expression returns %d value(s), %d requested", len(values), len(names))
+	}
+	for i, name := range names {
+		if isFunction(name) {
+			return false, fmt.Errorf("cannot assign to function name %q", name)
+		}
+		s.vars[name] = values[i]
+		if !silent {
+			s.print(name, values[i])
+		}
+	}
+	return false, nil
+}
+
+func isFunction(name string) bool {
+	if _, ok := replFunctions[name]; ok {
+		return true
+	}
+	for _, f := range expr.Functions() {
+		if f == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *session) print(name string, A matrix.Matrix) {
+	if A.Row == 1 && A.Col == 1 {
+		v := A.GetIndex(0)
+		if s.prec > 0 {
+			fmt.Fprintf(s.out, "%s = %s\n", name, strconv.FormatFloat(v, 'g', s.prec, 64))
+		} else {
+			fmt.Fprintf(s.out, "%s = %s\n", name, strconv.FormatFloat(v, 'g', -1, 64))
+		}
+		return
+	}
+	if s.prec > 0 {
+		fmt.Fprintf(s.out, "%s =\n%+.*v\n", name, s.prec, A)
+	} else {
+		fmt.Fprintf(s.out, "%s =\n%+v\n", name, A)
+	}
+}
+
+func (s *session) who() {
+	names := make([]string, 0, len(s.vars))
+	for name := range s.vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(s.out, "  %-10s %v\n", name, s.vars[name].Shape)
+	}
+}
+
+func (s *session) save(file string) error {
+	b, err := json.MarshalIndent(s.vars, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file, append(b, '\n'), 0o644)
+}
+
+func (s *session) load(file string) error {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	vars := map[string]matrix.Matrix{}
+	if err := json.Unmarshal(b, &vars); err != nil {
+		return fmt.Errorf("%s: %v", file, err)
+	}
+	for name, A := range vars {
+		s.vars[name] = A
+	}
+	return nil
+}
@@ -0,0 +1,206 @@
+package matrix
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// CSVOptions 分隔文本的读写选项，零值表示逗号分隔、无表头、最短浮点表示
+type CSVOptions struct {
+	// Comma 分隔符，默认 ','
+	Comma rune
+	// Comment 注释行前缀，须位于行首，0 表示不识别注释
+	Comment rune
+	// Header 读取时首个非注释行为表头
+	Header bool
+	// Columns 写入时输出的表头
+	Columns []string
+	// Missing 读取时视为缺失值（NaN）的标记，nil 时为 ""、"NA"、"N/A"
+	Missing []string
+	// NaN 写入时 NaN 的输出形式，默认 "NaN"
+	NaN string
+	// SkipCols 读取时跳过的列，从 0 开始计数
+	SkipCols []int
+	// Format 写入时的浮点格式，同 strconv.FormatFloat，默认 'g'
+	Format byte
+	// Precision 写入时的精度，含义同 strconv.FormatFloat，nil 时使用最短表示
+	Precision *int
+}
+
+// CSVError 带行号和列号的解析错误，行号和列号均从 1 开始。
+// 列号通常为字段序号；引号不匹配等语法错误时为出错字符在行内的字节位置，同 csv.ParseError
+type CSVError struct {
+	Line   int
+	Column int
+	Err    error
+}
+
+func (e *CSVError) Error() string {
+	return fmt.Sprintf("matrix: csv line %d, column %d: %v", e.Line, e.Column, e.Err)
+}
+
+func (e *CSVError) Unwrap() error {
+	return e.Err
+}
+
+func (o *CSVOptions) comma() rune {
+	if o == nil || o.Comma == 0 {
+		return ','
+	}
+	return o.Comma
+}
+
+func (o *CSVOptions) missing() []string {
+	if o == nil || o.Missing == nil {
+		return []string{"", "NA", "N/A"}
+	}
+	return o.Missing
+}
+
+// ReadCSV 读取分隔文本为矩阵，opts 为 nil 时使用默认选项。
+// 引号规则同 encoding/csv，字段首尾空白被去除。空行被忽略，每行的字段数必须一致；Header 为 true 时返回表头
+func ReadCSV(r io.Reader, opts *CSVOptions) (A Matrix, header []string, err error) {
+	missing := opts.missing()
+	skip := map[int]bool{}
+	cr := csv.NewReader(r)
+	cr.Comma = opts.comma()
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	if opts != nil {
+		cr.Comment = opts.Comment
+		for _, j := range opts.SkipCols {
+			skip[j] = true
+		}
+	}
+
+	var array []float64
+	shape := Shape{}
+	width := -1
+	for {
+		fields, rerr := cr.Read()
+		if rerr == io.EOF {
+			break
+		}
+		if rerr != nil {
+			var pe *csv.ParseError
+			if errors.As(rerr, &pe) && pe.Err != nil {
+				return A, nil, &CSVError{Line: pe.Line, Column: pe.Column, Err: pe.Err}
+			}
+			return A, nil, rerr
+		}
+		line, _ := cr.FieldPos(0)
+		if len(fields) == 1 && strings.TrimSpace(fields[0]) == "" {
+			continue
+		}
+		for j := range fields {
+			fields[j] = strings.TrimSpace(fields[j])
+		}
+
+		if width < 0 {
+			width = len(fields)
+		} else if len(fields) != width {
+			// 列号为第一个缺少或多出的字段
+			col := width
+			if len(fields) < col {
+				col = len(fields)
+			}
+			return A, nil, &CSVError{Line: line, Column: col + 1,
+				Err: fmt.Errorf("expect %d fields, got %d", width, len(fields))}
+		}
+
+		if opts != nil && opts.Header && header == nil {
+			for j, f := range fields {
+				if !skip[j] {
+					header = append(header, f)
+				}
+			}
+			continue
+		}
+		for j, f := range fields {
+			if skip[j] {
+				continue
+			}
+			v, perr := parseCSVFloat(f, missing)
+			if perr != nil {
+				line, _ := cr.FieldPos(j)
+				return A, nil, &CSVError{Line: line, Column: j + 1, Err: perr}
+			}
+			array = append(array, v)
+		}
+		shape.Row++
+	}
+
+	if shape.Row > 0 {
+		shape.Col = len(array) / shape.Row
+	}
+	A = NewMatrix(shape, array)
+	return A, header, nil
+}
+
+func parseCSVFloat(f string, missing []string) (float64, error) {
+	for _, m := range missing {
+		if f == m {
+			return math.NaN(), nil
+		}
+	}
+	v, err := strconv.ParseFloat(f, 64)
+	if err != nil {
+		var ne *strconv.NumError
+		if errors.As(err, &ne) {
+			err = fmt.Errorf("invalid number %q", f)
+		}
+		return 0, err
+	}
+	return v, nil
+}
+
+// WriteCSV 将矩阵写为分隔文本，opts 为 nil 时使用默认选项
+func WriteCSV(w io.Writer, A Matrix, opts *CSVOptions) error {
+	cw := csv.NewWriter(w)
+	cw.Comma = opts.comma()
+
+	format := byte('g')
+	prec := -1
+	nan := "NaN"
+	if opts != nil {
+		if opts.Format != 0 {
+			format = opts.Format
+		}
+		if opts.Precision != nil {
+			prec = *opts.Precision
+		}
+		if opts.NaN != "" {
+			nan = opts.NaN
+		}
+		if len(opts.Columns) > 0 {
+			if len(opts.Columns) != A.Col {
+				return fmt.Errorf("matrix: %d columns in header, matrix has %d", len(opts.Columns), A.Col)
+			}
+			if err := cw.Write(opts.Columns); err != nil {
+				return err
+			}
+		}
+	}
+
+	record := make([]string, A.Col)
+	for i := 0; i < A.Row; i++ {
+		for j := 0; j < A.Col; j++ {
+			v := A.Get(i, j)
+			if math.IsNaN(v) {
+				record[j] = nan
+			} else {
+				record[j] = strconv.FormatFloat(v, format, prec, 64)
+			}
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
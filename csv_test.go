@@ -0,0 +1,96 @@
+package matrix
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestReadCSV(t *testing.T) {
+	input := "# exported data\n" +
+		"id;x;\"y\"\n" +
+		"1; 1.5; 2\n" +
+		"\n" +
+		"2;NA;-3e2\n"
+
+	opts := &CSVOptions{Comma: ';', Comment: '#', Header: true, SkipCols: []int{0}}
+	A, header, err := ReadCSV(strings.NewReader(input), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Join(header, ",") != "x,y" {
+		t.Errorf("error method: ReadCSV header %v", header)
+	}
+	if A.Shape != (Shape{2, 2}) || A.Get(0, 0) != 1.5 || !math.IsNaN(A.Get(1, 0)) || A.Get(1, 1) != -300 {
+		t.Errorf("error method: ReadCSV got %v", A)
+	}
+}
+
+func TestReadCSVError(t *testing.T) {
+	cases := []struct {
+		input  string
+		line   int
+		column int
+	}{
+		{"1,2\n3,x\n", 2, 2},
+		{"1,2\n\n3\n", 3, 2},
+		{"1,2\n3,4,5\n", 2, 3},
+		{"1,\"2\n", 1, 6},
+	}
+
+	for _, c := range cases {
+		_, _, err := ReadCSV(strings.NewReader(c.input), &CSVOptions{Missing: []string{}})
+		var e *CSVError
+		if !errors.As(err, &e) || e.Line != c.line || e.Column != c.column {
+			t.Errorf("error method: ReadCSV(%q), got %v, want line %d, column %d", c.input, err, c.line, c.column)
+		}
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	A := Builder().Row().Link(1, 0.5).Link(math.NaN(), 1.0/3).Build()
+
+	var buf bytes.Buffer
+	prec := 3
+	opts := &CSVOptions{Comma: '\t', Columns: []string{"a", "b"}, NaN: "NA", Format: 'f', Precision: &prec}
+	if err := WriteCSV(&buf, A, opts); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "a\tb\n1.000\t0.500\nNA\t0.333\n" {
+		t.Errorf("error method: WriteCSV got %q", buf.String())
+	}
+
+	// 精度 0 输出整数
+	buf.Reset()
+	prec = 0
+	if err := WriteCSV(&buf, Builder().Row().Link(1.4, -2.6).Build(), &CSVOptions{Format: 'f', Precision: &prec}); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "1,-3\n" {
+		t.Errorf("error method: WriteCSV precision 0 got %q", buf.String())
+	}
+
+	buf.Reset()
+	B := Builder().Row().Link(1, 2.25).Link(-3, 4).Build()
+	if err := WriteCSV(&buf, B, nil); err != nil {
+		t.Fatal(err)
+	}
+	C, _, err := ReadCSV(&buf, nil)
+	if err != nil || !MatrixEqual(B, C) {
+		t.Errorf("error method: WriteCSV round trip, got %v, %v, want %v", C, err, B)
+	}
+
+	// 含换行和分隔符的表头被引号包裹，仍可读回
+	buf.Reset()
+	columns := []string{"a\nb", "c,d"}
+	if err := WriteCSV(&buf, B, &CSVOptions{Columns: columns}); err != nil {
+		t.Fatal(err)
+	}
+	C, header, err := ReadCSV(&buf, &CSVOptions{Header: true})
+	if err != nil || !MatrixEqual(B, C) || strings.Join(header, "|") != "a\nb|c,d" {
+		t.Errorf("error method: WriteCSV quoted header round trip, got %v, %q, %v, want %v, %q", C, header, err, B, columns)
+	}
+}
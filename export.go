@@ -0,0 +1,136 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ExportOptions LaTeX、Markdown、Matlab 导出选项，nil 表示默认选项
+type ExportOptions struct {
+	// Format 数值格式，同 strconv.FormatFloat，默认 'g'
+	Format byte
+	// Precision 精度，含义同 strconv.FormatFloat，nil 时使用最短表示
+	Precision *int
+	// Env LaTeX 矩阵环境，如 bmatrix、pmatrix、vmatrix，默认 bmatrix
+	Env string
+	// Columns Markdown 表头，默认为列号 1, 2, ...
+	Columns []string
+}
+
+func (o *ExportOptions) number(v float64) string {
+	format := byte('g')
+	prec := -1
+	if o != nil {
+		if o.Format != 0 {
+			format = o.Format
+		}
+		if o.Precision != nil {
+			prec = *o.Precision
+		}
+	}
+	return strconv.FormatFloat(v, format, prec, 64)
+}
+
+// LaTeX 导出为 LaTeX 矩阵环境源码，科学计数法写作 a \times 10^{b}
+func LaTeX(A Matrix, opts *ExportOptions) string {
+	env := "bmatrix"
+	if opts != nil && opts.Env != "" {
+		env = opts.Env
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "\\begin{%s}\n", env)
+	for i := 0; i < A.Row; i++ {
+		cells := make([]string, A.Col)
+		for j := 0; j < A.Col; j++ {
+			cells[j] = latexNumber(A.Get(i, j), opts)
+		}
+		sb.WriteString(strings.Join(cells, " & "))
+		if i < A.Row-1 {
+			sb.WriteString(" \\\\")
+		}
+		sb.WriteString("\n")
+	}
+	fmt.Fprintf(&sb, "\\end{%s}", env)
+	return sb.String()
+}
+
+func latexNumber(v float64, opts *ExportOptions) string {
+	switch {
+	case math.IsNaN(v):
+		return "\\mathrm{NaN}"
+	case math.IsInf(v, 1):
+		return "\\infty"
+	case math.IsInf(v, -1):
+		return "-\\infty"
+	}
+
+	s := opts.number(v)
+	k := strings.IndexAny(s, "eE")
+	if k < 0 {
+		return s
+	}
+	exp, err := strconv.Atoi(s[k+1:])
+	if err != nil {
+		return s
+	}
+	return fmt.Sprintf("%s \\times 10^{%d}", s[:k], exp)
+}
+
+// Markdown 导出为 Markdown 表格，数值列右对齐
+func Markdown(A Matrix, opts *ExportOptions) string {
+	header := make([]string, A.Col)
+	if opts != nil && len(opts.Columns) > 0 {
+		if len(opts.Columns) != A.Col {
+			panic(fmt.Sprintf("Markdown(A): %d columns in header, matrix has %d.", len(opts.Columns), A.Col))
+		}
+		for j, c := range opts.Columns {
+			header[j] = strings.ReplaceAll(c, "|", "\\|")
+		}
+	} else {
+		for j := range header {
+			header[j] = strconv.Itoa(j + 1)
+		}
+	}
+
+	align := make([]string, A.Col)
+	for j := range align {
+		align[j] = "---:"
+	}
+
+	lines := []string{
+		"| " + strings.Join(header, " | ") + " |",
+		"|" + strings.Join(align, "|") + "|",
+	}
+	cells := make([]string, A.Col)
+	for i := 0; i < A.Row; i++ {
+		for j := 0; j < A.Col; j++ {
+			cells[j] = opts.number(A.Get(i, j))
+		}
+		lines = append(lines, "| "+strings.Join(cells, " | ")+" |")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Matlab 导出为 Matlab/Octave 矩阵字面量，如 [1 2; 3 4]
+func Matlab(A Matrix, opts *ExportOptions) string {
+	rows := make([]string, A.Row)
+	cells := make([]string, A.Col)
+	for i := 0; i < A.Row; i++ {
+		for j := 0; j < A.Col; j++ {
+			cells[j] = opts.number(A.Get(i, j))
+		}
+		rows[i] = strings.Join(cells, " ")
+	}
+	return "[" + strings.Join(rows, "; ") + "]"
+}
+
+// ParseMatlab 解析以方括号包围的 Matlab 矩阵字面量，语法见 Parse
+func ParseMatlab(s string) (Matrix, error) {
+	if t := strings.TrimSpace(s); !strings.HasPrefix(t, "[") {
+		return Matrix{}, fmt.Errorf("matrix: matlab literal must be enclosed in []: %q", s)
+	}
+	return Parse(s)
+}
@@ -0,0 +1,61 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLaTeX(t *testing.T) {
+	A := Builder().Row().Link(1, 0.5).Link(1.5e-9, math.Inf(1)).Build()
+
+	expect := "\\begin{pmatrix}\n1 & 0.5 \\\\\n1.5 \\times 10^{-9} & \\infty\n\\end{pmatrix}"
+	if s := LaTeX(A, &ExportOptions{Env: "pmatrix"}); s != expect {
+		t.Errorf("error method: LaTeX, got %q", s)
+	}
+	if s := LaTeX(Eye(2), nil); s != "\\begin{bmatrix}\n1 & 0 \\\\\n0 & 1\n\\end{bmatrix}" {
+		t.Errorf("error method: LaTeX, got %q", s)
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	A := Builder().Row().Link(1, 1.0/3).Link(-2, 4).Build()
+
+	expect := "| x | y |\n|---:|---:|\n| 1.00 | 0.33 |\n| -2.00 | 4.00 |"
+	prec := 2
+	if s := Markdown(A, &ExportOptions{Format: 'f', Precision: &prec, Columns: []string{"x", "y"}}); s != expect {
+		t.Errorf("error method: Markdown, got %q", s)
+	}
+
+	// 精度 0 输出整数
+	prec = 0
+	if s := Matlab(A, &ExportOptions{Format: 'f', Precision: &prec}); s != "[1 0; -2 4]" {
+		t.Errorf("error method: Matlab precision 0, got %q", s)
+	}
+}
+
+func TestMatlab(t *testing.T) {
+	A := Builder().Row().Link(1, -2.5).Link(math.NaN(), 4).Build()
+
+	if s := Matlab(A, nil); s != "[1 -2.5; NaN 4]" {
+		t.Errorf("error method: Matlab, got %q", s)
+	}
+
+	B, err := ParseMatlab(Matlab(A, nil))
+	if err != nil || B.Shape != A.Shape || B.Get(0, 1) != -2.5 || !math.IsNaN(B.Get(1, 0)) {
+		t.Error("error method: ParseMatlab round trip")
+	}
+
+	C := Builder().Row().Link(1, 0.5).Link(3, 4).Build()
+	for _, s := range []string{C.String(), "[1 0.5\n 3 4]", "[1, 5e-1; 3, 4;]"} {
+		D, err := ParseMatlab(s)
+		if err != nil || !MatrixEqual(C, D) {
+			t.Errorf("error method: ParseMatlab(%q)", s)
+		}
+	}
+
+	for _, s := range []string{"1 2", "[1 2; 3]", "[1 x]"} {
+		if _, err := ParseMatlab(s); err == nil {
+			t.Errorf("error method: ParseMatlab accepts %q", s)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package expr
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/mrfyo/matrix"
+)
+
+// builtin 内置函数。shape 在编译期由参数形状推导结果形状；
+// sized 非 nil 时参数必须是编译期常量，表示结果的形状
+type builtin struct {
+	minArgs, maxArgs int
+	shape            func(args []matrix.Shape) (matrix.Shape, error)
+	call             func(args []matrix.Matrix) matrix.Matrix
+	sized            func(shape matrix.Shape) matrix.Matrix
+}
+
+var scalarShape = matrix.Shape{Row: 1, Col: 1}
+
+func squareShape(args []matrix.Shape) error {
+	if s := args[0]; s.Row != s.Col || s.Size() == 0 {
+		return fmt.Errorf("matrix must be square, got %v", s)
+	}
+	return nil
+}
+
+func isVectorShape(s matrix.Shape) bool {
+	return s.Row == 1 || s.Col == 1
+}
+
+var builtins = map[string]builtin{
+	"det": {1, 1, func(args []matrix.Shape) (matrix.Shape, error) {
+		return scalarShape, squareShape(args)
+	}, func(args []matrix.Matrix) matrix.Matrix {
+		return scalarMatrix(matrix.Det(args[0]))
+	}, nil},
+	"inv": {1, 1, func(args []matrix.Shape) (matrix.Shape, error) {
+		return args[0], squareShape(args)
+	}, func(args []matrix.Matrix) matrix.Matrix {
+		// 列主元消元，比 matrix.Inv 更稳定
+		return matrix.Solve(args[0], matrix.Eye(args[0].Row))
+	}, nil},
+	"chol": {1, 1, func(args []matrix.Shape) (matrix.Shape, error) {
+		return args[0], squareShape(args)
+	}, func(args []matrix.Matrix) matrix.Matrix {
+		L, _ := matrix.Cholesky(args[0])
+		return L
+	}, nil},
+	"norm": {1, 1, func(args []matrix.Shape) (matrix.Shape, error) {
+		return scalarShape, nil
+	}, func(args []matrix.Matrix) matrix.Matrix {
+		return scalarMatrix(matrix.Norm(args[0]))
+	}, nil},
+	"transpose": {1, 1, func(args []matrix.Shape) (matrix.Shape, error) {
+		return matrix.Shape{Row: args[0].Col, Col: args[0].Row}, nil
+	}, func(args []matrix.Matrix) matrix.Matrix {
+		return args[0].T()
+	}, nil},
+	"conv": {2, 2, func(args []matrix.Shape) (matrix.Shape, error) {
+		f, g := args[0], args[1]
+		if !isVectorShape(f) || !isVectorShape(g) || f.Size() == 0 || g.Size() == 0 {
+			return matrix.Shape{}, fmt.Errorf("arguments must be non-empty vectors, got %v and %v", f, g)
+		}
+		n := f.Size() + g.Size() - 1
+		if f.Col == 1 && f.Row > 1 {
+			return matrix.Shape{Row: n, Col: 1}, nil
+		}
+		return matrix.Shape{Row: 1, Col: n}, nil
+	}, func(args []matrix.Matrix) matrix.Matrix {
+		// 结果方向与第一个参数相同
+		Y := matrix.Conv(asRow(args[0]), asRow(args[1]))
+		if args[0].Col == 1 && args[0].Row > 1 {
+			return Y.T()
+		}
+		return Y
+	}, nil},
+	"eye":   {1, 1, nil, nil, func(s matrix.Shape) matrix.Matrix { return matrix.Eye(s.Row) }},
+	"zeros": {1, 2, nil, nil, matrix.Zeros},
+	"ones":  {1, 2, nil, nil, matrix.Ones},
+}
+
+// asRow 将向量转换为行向量
+func asRow(V matrix.Matrix) matrix.Matrix {
+	if V.Row == 1 {
+		return V
+	}
+	return V.T()
+}
+
+// Functions 返回内置函数名，按字典序排列
+func Functions() []string {
+	names := make([]string, 0, len(builtins))
+	for name := range builtins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
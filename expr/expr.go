@@ -0,0 +1,347 @@
+// Package expr 编译并求值矩阵表达式，如 inv(A' * A) * A' * b。
+//
+// 语法：
+//
+//	expr    = term { ("+" | "-") term }
+//	term    = unary { ("*" | ".*" | "/" | "\") unary }
+//	unary   = { "+" | "-" } postfix
+//	postfix = primary { "'" }
+//	primary = number | ident | ident "(" [ expr { "," expr } ] ")" | "(" expr ")" | "[" 矩阵字面量 "]"
+//
+// 运算符 + - * .* \ ' 分别对应 Add、Sub、Dot、Mul、Solve、T，A / B 等价于 (B' \ A')'。
+// 标量即 1x1 矩阵，与矩阵做加减、逐元素乘时广播，做乘除时按比例缩放。矩阵字面量语法见 matrix.Parse。
+//
+// 编译时依据变量形状检查每一步运算，得到的 Program 可以对形状相同的不同取值重复求值。
+package expr
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/mrfyo/matrix"
+)
+
+// Error 表达式的语法或形状错误，Pos 为出错位置在源码中的字节偏移
+type Error struct {
+	Pos int
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("expr: column %d: %s", e.Pos+1, e.Msg)
+}
+
+// evalFunc 编译后的求值函数，env 中变量的形状已经检查
+type evalFunc func(env map[string]matrix.Matrix) matrix.Matrix
+
+// Program 编译后的表达式
+type Program struct {
+	src   string
+	vars  map[string]matrix.Shape
+	shape matrix.Shape
+	eval  evalFunc
+	// alias 为 true 时结果直接引用变量或常量，返回前需要复制
+	alias bool
+}
+
+// Compile 以 env 中变量的形状编译表达式
+func Compile(src string, env map[string]matrix.Matrix) (*Program, error) {
+	shapes := make(map[string]matrix.Shape, len(env))
+	for name, A := range env {
+		shapes[name] = A.Shape
+	}
+	return CompileShapes(src, shapes)
+}
+
+// CompileShapes 以给定的变量形状编译表达式
+func CompileShapes(src string, shapes map[string]matrix.Shape) (*Program, error) {
+	n, err := parse(src)
+	if err != nil {
+		return nil, err
+	}
+	c := &compiler{shapes: shapes, vars: map[string]matrix.Shape{}}
+	v, err := c.compile(n)
+	if err != nil {
+		return nil, err
+	}
+	return &Program{src: src, vars: c.vars, shape: v.shape, eval: v.eval, alias: v.ref}, nil
+}
+
+// MustCompile 同 Compile，出错时 panic
+func MustCompile(src string, env map[string]matrix.Matrix) *Program {
+	p, err := Compile(src, env)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// Eval 编译并求值一次表达式
+func Eval(src string, env map[string]matrix.Matrix) (matrix.Matrix, error) {
+	p, err := Compile(src, env)
+	if err != nil {
+		return matrix.Matrix{}, err
+	}
+	return p.Eval(env)
+}
+
+// String 返回表达式源码
+func (p *Program) String() string {
+	return p.src
+}
+
+// Shape 返回结果的形状
+func (p *Program) Shape() matrix.Shape {
+	return p.shape
+}
+
+// Vars 返回表达式引用的变量名，按字典序排列
+func (p *Program) Vars() []string {
+	names := make([]string, 0, len(p.vars))
+	for name := range p.vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Eval 以 env 中的变量求值，变量形状必须与编译时一致。
+// 奇异矩阵求解等数值错误以 error 返回，奇异时错误包装 matrix.ErrSingular
+func (p *Program) Eval(env map[string]matrix.Matrix) (R matrix.Matrix, err error) {
+	for name, shape := range p.vars {
+		A, ok := env[name]
+		if !ok {
+			return matrix.Matrix{}, fmt.Errorf("expr: undefined variable %q", name)
+		}
+		if A.Shape != shape {
+			return matrix.Matrix{}, fmt.Errorf("expr: variable %s has shape %v, compiled for %v", name, A.Shape, shape)
+		}
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			if e, ok := r.(error); ok {
+				err = fmt.Errorf("expr: %w", e)
+			} else {
+				err = fmt.Errorf("expr: %v", r)
+			}
+		}
+	}()
+	R = p.eval(env)
+	if p.alias {
+		R = R.Copy()
+	}
+	return R, nil
+}
+
+// value 编译后的子表达式
+type value struct {
+	shape matrix.Shape
+	eval  evalFunc
+	// constant 非 nil 时为编译期已知的常量
+	constant *matrix.Matrix
+	// ref 为 true 时 eval 直接返回变量或常量本身，如 A、+A、(A)
+	ref bool
+}
+
+type compiler struct {
+	shapes map[string]matrix.Shape
+	vars   map[string]matrix.Shape
+}
+
+func (c *compiler) compile(n node) (value, error) {
+	switch n := n.(type) {
+	case *constNode:
+		A := n.value
+		return value{shape: A.Shape, eval: func(map[string]matrix.Matrix) matrix.Matrix { return A }, constant: &A, ref: true}, nil
+	case *identNode:
+		shape, ok := c.shapes[n.name]
+		if !ok {
+			return value{}, &Error{n.pos, fmt.Sprintf("undefined variable %q", n.name)}
+		}
+		c.vars[n.name] = shape
+		name := n.name
+		return value{shape: shape, eval: func(env map[string]matrix.Matrix) matrix.Matrix { return env[name] }, ref: true}, nil
+	case *unaryNode:
+		x, err := c.compile(n.x)
+		if err != nil || n.op == "+" {
+			return x, err
+		}
+		return value{shape: x.shape, eval: func(env map[string]matrix.Matrix) matrix.Matrix {
+			return x.eval(env).ScaleMul(-1)
+		}}, nil
+	case *transposeNode:
+		x, err := c.compile(n.x)
+		if err != nil {
+			return value{}, err
+		}
+		return value{shape: matrix.Shape{Row: x.shape.Col, Col: x.shape.Row}, eval: func(env map[string]matrix.Matrix) matrix.Matrix {
+			return x.eval(env).T()
+		}}, nil
+	case *binaryNode:
+		l, err := c.compile(n.l)
+		if err != nil {
+			return value{}, err
+		}
+		r, err := c.compile(n.r)
+		if err != nil {
+			return value{}, err
+		}
+		v, err := binary(n.op, l, r)
+		if err != nil {
+			return value{}, &Error{n.pos, err.Error()}
+		}
+		return v, nil
+	case *callNode:
+		return c.call(n)
+	}
+	return value{}, &Error{n.position(), "unknown expression"}
+}
+
+func (c *compiler) call(n *callNode) (value, error) {
+	f, ok := builtins[n.name]
+	if !ok {
+		return value{}, &Error{n.pos, fmt.Sprintf("undefined function %q", n.name)}
+	}
+	if len(n.args) < f.minArgs || len(n.args) > f.maxArgs {
+		want := fmt.Sprint(f.minArgs)
+		if f.maxArgs > f.minArgs {
+			want = fmt.Sprintf("%d to %d", f.minArgs, f.maxArgs)
+		}
+		return value{}, &Error{n.pos, fmt.Sprintf("%s takes %s argument(s), got %d", n.name, want, len(n.args))}
+	}
+
+	args := make([]value, len(n.args))
+	shapes := make([]matrix.Shape, len(n.args))
+	for i, a := range n.args {
+		v, err := c.compile(a)
+		if err != nil {
+			return value{}, err
+		}
+		args[i], shapes[i] = v, v.shape
+	}
+
+	if f.sized != nil {
+		shape, err := constShape(n.name, args)
+		if err != nil {
+			return value{}, &Error{n.pos, err.Error()}
+		}
+		return value{shape: shape, eval: func(map[string]matrix.Matrix) matrix.Matrix { return f.sized(shape) }}, nil
+	}
+
+	shape, err := f.shape(shapes)
+	if err != nil {
+		return value{}, &Error{n.pos, fmt.Sprintf("%s: %v", n.name, err)}
+	}
+	return value{shape: shape, eval: func(env map[string]matrix.Matrix) matrix.Matrix {
+		vs := make([]matrix.Matrix, len(args))
+		for i, a := range args {
+			vs[i] = a.eval(env)
+		}
+		return f.call(vs)
+	}}, nil
+}
+
+// constShape 由编译期常量参数确定 eye、zeros、ones 的形状
+func constShape(name string, args []value) (matrix.Shape, error) {
+	dims := make([]int, len(args))
+	for i, a := range args {
+		if a.constant == nil || !isScalar(a.shape) {
+			return matrix.Shape{}, errors.New("size must be a constant")
+		}
+		v := a.constant.GetIndex(0)
+		if v < 0 || v != float64(int(v)) {
+			return matrix.Shape{}, fmt.Errorf("size must be a non-negative integer, got %v", v)
+		}
+		dims[i] = int(v)
+	}
+	if len(dims) == 1 {
+		return matrix.Shape{Row: dims[0], Col: dims[0]}, nil
+	}
+	return matrix.Shape{Row: dims[0], Col: dims[1]}, nil
+}
+
+func scalarMatrix(v float64) matrix.Matrix {
+	return matrix.NewVector([]float64{v}, 1)
+}
+
+func isScalar(s matrix.Shape) bool {
+	return s.Row == 1 && s.Col == 1
+}
+
+func binary(op string, l, r value) (value, error) {
+	ls, rs := l.shape, r.shape
+	mismatch := func() error {
+		return fmt.Errorf("operator %s: shape mismatch %v and %v", op, ls, rs)
+	}
+
+	switch op {
+	case "+", "-", ".*":
+		shape := ls
+		switch {
+		case isScalar(ls) && !isScalar(rs):
+			shape = rs
+		case ls != rs && !isScalar(rs):
+			return value{}, mismatch()
+		}
+		// 标量广播为同形矩阵
+		apply := map[string]func(a, b matrix.Matrix) matrix.Matrix{
+			"+":  matrix.Matrix.Add,
+			"-":  matrix.Matrix.Sub,
+			".*": matrix.Matrix.Mul,
+		}[op]
+		return value{shape: shape, eval: func(env map[string]matrix.Matrix) matrix.Matrix {
+			a, b := l.eval(env), r.eval(env)
+			if a.Shape != shape {
+				a = matrix.Full(shape, a.GetIndex(0))
+			}
+			if b.Shape != shape {
+				b = matrix.Full(shape, b.GetIndex(0))
+			}
+			return apply(a, b)
+		}}, nil
+	case "*":
+		switch {
+		case isScalar(ls):
+			return value{shape: rs, eval: func(env map[string]matrix.Matrix) matrix.Matrix {
+				return r.eval(env).ScaleMul(l.eval(env).GetIndex(0))
+			}}, nil
+		case isScalar(rs):
+			return value{shape: ls, eval: func(env map[string]matrix.Matrix) matrix.Matrix {
+				return l.eval(env).ScaleMul(r.eval(env).GetIndex(0))
+			}}, nil
+		case ls.Col != rs.Row:
+			return value{}, fmt.Errorf("operator *: inner dimensions mismatch %v and %v", ls, rs)
+		}
+		return value{shape: matrix.Shape{Row: ls.Row, Col: rs.Col}, eval: func(env map[string]matrix.Matrix) matrix.Matrix {
+			return l.eval(env).Dot(r.eval(env))
+		}}, nil
+	case "/":
+		if isScalar(rs) {
+			return value{shape: ls, eval: func(env map[string]matrix.Matrix) matrix.Matrix {
+				return l.eval(env).ScaleMul(1 / r.eval(env).GetIndex(0))
+			}}, nil
+		}
+		if rs.Row != rs.Col || ls.Col != rs.Col {
+			return value{}, mismatch()
+		}
+		// A / B = (B' \ A')'
+		return value{shape: ls, eval: func(env map[string]matrix.Matrix) matrix.Matrix {
+			return matrix.Solve(r.eval(env).T(), l.eval(env).T()).T()
+		}}, nil
+	case "\\":
+		if isScalar(ls) {
+			return value{shape: rs, eval: func(env map[string]matrix.Matrix) matrix.Matrix {
+				return r.eval(env).ScaleMul(1 / l.eval(env).GetIndex(0))
+			}}, nil
+		}
+		if ls.Row != ls.Col || ls.Row != rs.Row {
+			return value{}, mismatch()
+		}
+		return value{shape: matrix.Shape{Row: ls.Col, Col: rs.Col}, eval: func(env map[string]matrix.Matrix) matrix.Matrix {
+			return matrix.Solve(l.eval(env), r.eval(env))
+		}}, nil
+	}
+	return value{}, fmt.Errorf("unknown operator %s", op)
+}
@@ -0,0 +1,129 @@
+package expr
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/mrfyo/matrix"
+)
+
+func TestEval(t *testing.T) {
+	env := map[string]matrix.Matrix{
+		"A": matrix.NewMatrix(matrix.Shape{Row: 3, Col: 2}, []float64{1, 1, 1, 2, 1, 3}),
+		"b": matrix.NewVector([]float64{1, 2, 2}, 1),
+		"M": matrix.NewMatrix(matrix.Shape{Row: 2, Col: 2}, []float64{2, 1, 1, 3}),
+		"k": matrix.NewVector([]float64{2}, 1),
+	}
+	A, b, M := env["A"], env["b"], env["M"]
+	normal := matrix.Solve(A.T().Dot(A), A.T().Dot(b))
+
+	cases := []struct {
+		src  string
+		want matrix.Matrix
+	}{
+		{"inv(A' * A) * A' * b", normal},
+		{"(A' * A) \\ (A' * b)", normal},
+		{"M + 1", M.Add(matrix.Ones(M.Shape))},
+		{"-M .* M - k", M.Mul(M).ScaleMul(-1).Sub(matrix.Full(M.Shape, 2))},
+		{"2 * M / k", M},
+		{"M / M", matrix.Eye(2)},
+		{"[1 2] / M", matrix.Solve(M.T(), matrix.NewVector([]float64{1, 2}, 1)).T()},
+		{"det(M) + norm([3 4])", matrix.NewVector([]float64{10}, 1)},
+		{"transpose(b) * eye(3) + zeros(1, 3)", b.T()},
+		{"conv([1 1], [1; -1])", matrix.NewVector([]float64{1, 0, -1}, 2)},
+		{"chol(M * M') * chol(M * M')'", M.Dot(M.T())},
+		{"1.5e1 % comment", matrix.NewVector([]float64{15}, 1)},
+	}
+	for _, c := range cases {
+		got, err := Eval(c.src, env)
+		if err != nil {
+			t.Errorf("Eval(%q): %v", c.src, err)
+			continue
+		}
+		if got.Shape != c.want.Shape || !matrix.MatrixEqual(got, c.want) {
+			t.Errorf("Eval(%q) = %v, want %v", c.src, got, c.want)
+		}
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	shapes := map[string]matrix.Shape{
+		"A": {Row: 3, Col: 2},
+		"b": {Row: 3, Col: 1},
+	}
+	cases := []struct {
+		src string
+		pos int
+		msg string
+	}{
+		{"A * b", 2, "inner dimensions mismatch"},
+		{"A + b'", 2, "shape mismatch"},
+		{"A \\ b", 2, "shape mismatch"},
+		{"inv(A)", 0, "square"},
+		{"x + 1", 0, `undefined variable "x"`},
+		{"foo(A)", 0, `undefined function "foo"`},
+		{"det(A, b)", 0, "takes 1 argument(s)"},
+		{"zeros(b)", 0, "constant"},
+		{"eye(1.5)", 0, "non-negative integer"},
+		{"(A", 2, "expected ')'"},
+		{"A +", 3, "unexpected end of input"},
+		{"[1 2; 3]", 6, "row 2"},
+		{"A # b", 2, "unexpected character"},
+	}
+	for _, c := range cases {
+		_, err := CompileShapes(c.src, shapes)
+		var e *Error
+		if !errors.As(err, &e) || e.Pos != c.pos || !strings.Contains(e.Msg, c.msg) {
+			t.Errorf("CompileShapes(%q): error %v, want position %d and %q", c.src, err, c.pos, c.msg)
+		}
+	}
+}
+
+func TestProgram(t *testing.T) {
+	env := map[string]matrix.Matrix{
+		"A": matrix.NewMatrix(matrix.Shape{Row: 2, Col: 2}, []float64{1, 2, 3, 4}),
+		"x": matrix.NewVector([]float64{1, 1}, 1),
+	}
+	p := MustCompile("A \\ x", env)
+	if p.Shape() != (matrix.Shape{Row: 2, Col: 1}) || strings.Join(p.Vars(), ",") != "A,x" || p.String() != "A \\ x" {
+		t.Fatalf("program: shape %v, vars %v, source %q", p.Shape(), p.Vars(), p)
+	}
+
+	// 同一个程序对不同取值重复求值
+	for _, rhs := range [][]float64{{1, 1}, {5, 6}} {
+		env["x"] = matrix.NewVector(rhs, 1)
+		got, err := p.Eval(env)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := matrix.Solve(env["A"], env["x"]); !matrix.MatrixEqual(got, want) {
+			t.Errorf("Eval with x = %v: got %v, want %v", rhs, got, want)
+		}
+	}
+
+	env["A"] = matrix.NewMatrix(matrix.Shape{Row: 2, Col: 2}, []float64{1, 2, 2, 4})
+	if _, err := p.Eval(env); !errors.Is(err, matrix.ErrSingular) {
+		t.Errorf("singular system: got error %v, want ErrSingular", err)
+	}
+
+	env["x"] = matrix.NewVector([]float64{1, 2, 3}, 1)
+	if _, err := p.Eval(env); err == nil || !strings.Contains(err.Error(), "compiled for") {
+		t.Errorf("shape change: got error %v", err)
+	}
+	delete(env, "x")
+	if _, err := p.Eval(env); err == nil || !strings.Contains(err.Error(), "undefined") {
+		t.Errorf("missing variable: got error %v", err)
+	}
+
+	// 结果不能与变量共享存储
+	env["x"] = matrix.NewVector([]float64{1, 1}, 1)
+	for _, src := range []string{"x", "(x)", "+x", "+(+x)", "[1; 2]", "+[1; 2]"} {
+		p := MustCompile(src, env)
+		got, _ := p.Eval(env)
+		got.SetIndex(0, 9)
+		if again, _ := p.Eval(env); env["x"].GetIndex(0) != 1 || again.GetIndex(0) == 9 {
+			t.Errorf("Eval(%q) result aliases the environment", src)
+		}
+	}
+}
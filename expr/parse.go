@@ -0,0 +1,318 @@
+package expr
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mrfyo/matrix"
+)
+
+type tokKind int
+
+const (
+	tEOF tokKind = iota
+	tNum
+	tIdent
+	tMatrix
+	tOp
+	tLParen
+	tRParen
+	tComma
+)
+
+type tok struct {
+	kind tokKind
+	text string
+	num  float64
+	mat  matrix.Matrix
+	pos  int
+}
+
+func lex(src string) ([]tok, error) {
+	var toks []tok
+	i := 0
+	for i < len(src) {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			i++
+		case c == '%':
+			i = len(src)
+		case c >= '0' && c <= '9' || (c == '.' && i+1 < len(src) && src[i+1] >= '0' && src[i+1] <= '9'):
+			j := i
+			for j < len(src) && (isDigitByte(src[j]) || src[j] == '.') {
+				j++
+			}
+			if j < len(src) && (src[j] == 'e' || src[j] == 'E') {
+				k := j + 1
+				if k < len(src) && (src[k] == '+' || src[k] == '-') {
+					k++
+				}
+				if k < len(src) && isDigitByte(src[k]) {
+					for k < len(src) && isDigitByte(src[k]) {
+						k++
+					}
+					j = k
+				}
+			}
+			v, err := strconv.ParseFloat(src[i:j], 64)
+			if err != nil {
+				return nil, &Error{i, fmt.Sprintf("invalid number %q", src[i:j])}
+			}
+			toks = append(toks, tok{kind: tNum, text: src[i:j], num: v, pos: i})
+			i = j
+		case isIdentStart(c):
+			j := i
+			for j < len(src) && (isIdentStart(src[j]) || isDigitByte(src[j])) {
+				j++
+			}
+			toks = append(toks, tok{kind: tIdent, text: src[i:j], pos: i})
+			i = j
+		case c == '[':
+			depth, j := 0, i
+			for ; j < len(src); j++ {
+				if src[j] == '[' {
+					depth++
+				} else if src[j] == ']' {
+					depth--
+					if depth == 0 {
+						break
+					}
+				}
+			}
+			if j == len(src) {
+				return nil, &Error{i, "unclosed '['"}
+			}
+			A, err := matrix.Parse(src[i : j+1])
+			if err != nil {
+				var pe *matrix.ParseError
+				if errors.As(err, &pe) {
+					return nil, &Error{i + pe.Offset, pe.Msg}
+				}
+				return nil, &Error{i, err.Error()}
+			}
+			toks = append(toks, tok{kind: tMatrix, text: src[i : j+1], mat: A, pos: i})
+			i = j + 1
+		case c == '(':
+			toks = append(toks, tok{kind: tLParen, text: "(", pos: i})
+			i++
+		case c == ')':
+			toks = append(toks, tok{kind: tRParen, text: ")", pos: i})
+			i++
+		case c == ',':
+			toks = append(toks, tok{kind: tComma, text: ",", pos: i})
+			i++
+		case c == '.' && i+1 < len(src) && src[i+1] == '*':
+			toks = append(toks, tok{kind: tOp, text: ".*", pos: i})
+			i += 2
+		case strings.IndexByte("+-*/\\'", c) >= 0:
+			toks = append(toks, tok{kind: tOp, text: string(c), pos: i})
+			i++
+		default:
+			return nil, &Error{i, fmt.Sprintf("unexpected character %q", c)}
+		}
+	}
+	return append(toks, tok{kind: tEOF, pos: len(src)}), nil
+}
+
+func isDigitByte(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// node 语法树节点
+type node interface {
+	position() int
+}
+
+type (
+	constNode struct {
+		pos   int
+		value matrix.Matrix
+	}
+	identNode struct {
+		pos  int
+		name string
+	}
+	unaryNode struct {
+		pos int
+		op  string
+		x   node
+	}
+	binaryNode struct {
+		pos  int
+		op   string
+		l, r node
+	}
+	transposeNode struct {
+		pos int
+		x   node
+	}
+	callNode struct {
+		pos  int
+		name string
+		args []node
+	}
+)
+
+func (n *constNode) position() int     { return n.pos }
+func (n *identNode) position() int     { return n.pos }
+func (n *unaryNode) position() int     { return n.pos }
+func (n *binaryNode) position() int    { return n.pos }
+func (n *transposeNode) position() int { return n.pos }
+func (n *callNode) position() int      { return n.pos }
+
+type parser struct {
+	toks []tok
+	i    int
+}
+
+// parse 解析完整的表达式
+func parse(src string) (node, error) {
+	toks, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{toks: toks}
+	n, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tEOF {
+		return nil, &Error{t.pos, fmt.Sprintf("unexpected %q", t.text)}
+	}
+	return n, nil
+}
+
+func (p *parser) peek() tok {
+	return p.toks[p.i]
+}
+
+func (p *parser) take() tok {
+	t := p.toks[p.i]
+	if t.kind != tEOF {
+		p.i++
+	}
+	return t
+}
+
+func (p *parser) isOp(ops ...string) bool {
+	t := p.peek()
+	if t.kind != tOp {
+		return false
+	}
+	for _, op := range ops {
+		if t.text == op {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *parser) expr() (node, error) {
+	l, err := p.term()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp("+", "-") {
+		t := p.take()
+		r, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		l = &binaryNode{pos: t.pos, op: t.text, l: l, r: r}
+	}
+	return l, nil
+}
+
+func (p *parser) term() (node, error) {
+	l, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp("*", ".*", "/", "\\") {
+		t := p.take()
+		r, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		l = &binaryNode{pos: t.pos, op: t.text, l: l, r: r}
+	}
+	return l, nil
+}
+
+func (p *parser) unary() (node, error) {
+	if p.isOp("+", "-") {
+		t := p.take()
+		x, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryNode{pos: t.pos, op: t.text, x: x}, nil
+	}
+	return p.postfix()
+}
+
+func (p *parser) postfix() (node, error) {
+	x, err := p.primary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp("'") {
+		t := p.take()
+		x = &transposeNode{pos: t.pos, x: x}
+	}
+	return x, nil
+}
+
+func (p *parser) primary() (node, error) {
+	t := p.take()
+	switch t.kind {
+	case tNum:
+		return &constNode{pos: t.pos, value: scalarMatrix(t.num)}, nil
+	case tMatrix:
+		return &constNode{pos: t.pos, value: t.mat}, nil
+	case tIdent:
+		if p.peek().kind != tLParen {
+			return &identNode{pos: t.pos, name: t.text}, nil
+		}
+		p.take()
+		call := &callNode{pos: t.pos, name: t.text}
+		if p.peek().kind == tRParen {
+			p.take()
+			return call, nil
+		}
+		for {
+			arg, err := p.expr()
+			if err != nil {
+				return nil, err
+			}
+			call.args = append(call.args, arg)
+			next := p.take()
+			if next.kind == tRParen {
+				return call, nil
+			}
+			if next.kind != tComma {
+				return nil, &Error{next.pos, fmt.Sprintf("expected ',' or ')' in call to %s", t.text)}
+			}
+		}
+	case tLParen:
+		x, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		if next := p.take(); next.kind != tRParen {
+			return nil, &Error{next.pos, "expected ')'"}
+		}
+		return x, nil
+	case tEOF:
+		return nil, &Error{t.pos, "unexpected end of input"}
+	default:
+		return nil, &Error{t.pos, fmt.Sprintf("unexpected %q", t.text)}
+	}
+}
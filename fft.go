@@ -0,0 +1,367 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"math/cmplx"
+)
+
+// convFFTThreshold 两个序列长度均不小于该值时 Conv 改用 FFT 计算
+const convFFTThreshold = 64
+
+// dftMaxPrime 不超过该长度的素数因子直接用 DFT 计算，更大的素数长度采用 Bluestein 算法
+const dftMaxPrime = 32
+
+// FFT 快速傅里叶变换，任意长度（混合基）
+func FFT(x []complex128) []complex128 {
+	return fft(x, false)
+}
+
+// IFFT 快速傅里叶逆变换
+func IFFT(x []complex128) []complex128 {
+	y := fft(x, true)
+	n := complex(float64(len(y)), 0)
+	for i := range y {
+		y[i] /= n
+	}
+	return y
+}
+
+// RFFT 实向量的快速傅里叶变换，只返回非负频率部分，长度为 n/2+1
+func RFFT(X Matrix) []complex128 {
+	if !IsVector(X) {
+		panic("RFFT(X): X must be vector.")
+	}
+	n := X.Size()
+	x := make([]complex128, n)
+	for i := 0; i < n; i++ {
+		x[i] = complex(X.GetIndex(i), 0)
+	}
+	return FFT(x)[:n/2+1]
+}
+
+// IRFFT RFFT 的逆变换，n 为原实向量长度，返回行向量
+func IRFFT(y []complex128, n int) Matrix {
+	if len(y) != n/2+1 {
+		panic(fmt.Sprintf("IRFFT(y, n): len(y) must be n/2+1, got %d and n = %d.", len(y), n))
+	}
+	// 按共轭对称补全负频率
+	x := make([]complex128, n)
+	copy(x, y)
+	for k := len(y); k < n; k++ {
+		x[k] = cmplx.Conj(y[n-k])
+	}
+	z := IFFT(x)
+	array := make([]float64, n)
+	for i := range z {
+		array[i] = real(z[i])
+	}
+	return NewVector(array, 2)
+}
+
+// FFT2 矩阵的二维快速傅里叶变换，返回 Row x Col 的复数数组
+func FFT2(A Matrix) [][]complex128 {
+	X := make([][]complex128, A.Row)
+	for i := 0; i < A.Row; i++ {
+		X[i] = make([]complex128, A.Col)
+		for j := 0; j < A.Col; j++ {
+			X[i][j] = complex(A.Get(i, j), 0)
+		}
+	}
+	return fft2(X, false)
+}
+
+// IFFT2 二维快速傅里叶逆变换
+func IFFT2(X [][]complex128) [][]complex128 {
+	Y := fft2(X, true)
+	if len(Y) == 0 {
+		return Y
+	}
+	n := complex(float64(len(Y)*len(Y[0])), 0)
+	for i := range Y {
+		for j := range Y[i] {
+			Y[i][j] /= n
+		}
+	}
+	return Y
+}
+
+func fft2(X [][]complex128, inverse bool) [][]complex128 {
+	m := len(X)
+	if m == 0 {
+		return nil
+	}
+	n := len(X[0])
+
+	Y := make([][]complex128, m)
+	for i := range X {
+		if len(X[i]) != n {
+			panic("FFT2: rows must have equal length.")
+		}
+		Y[i] = fft(X[i], inverse)
+	}
+
+	col := make([]complex128, m)
+	for j := 0; j < n; j++ {
+		for i := 0; i < m; i++ {
+			col[i] = Y[i][j]
+		}
+		c := fft(col, inverse)
+		for i := 0; i < m; i++ {
+			Y[i][j] = c[i]
+		}
+	}
+	return Y
+}
+
+// fft 未归一化的变换，inverse 为 true 时使用正指数
+func fft(x []complex128, inverse bool) []complex128 {
+	n := len(x)
+	y := make([]complex128, n)
+	if n == 0 {
+		return y
+	}
+	if n&(n-1) == 0 {
+		copy(y, x)
+		radix2(y, inverse)
+		return y
+	}
+
+	p := smallestFactor(n)
+	if p == n {
+		if n <= dftMaxPrime {
+			return dft(x, inverse)
+		}
+		return bluestein(x, inverse)
+	}
+
+	// n = p * m：拆分为 p 个长度为 m 的子序列分别变换后合并
+	m := n / p
+	sub := make([][]complex128, p)
+	buf := make([]complex128, m)
+	for r := 0; r < p; r++ {
+		for k := 0; k < m; k++ {
+			buf[k] = x[k*p+r]
+		}
+		sub[r] = fft(buf, inverse)
+	}
+
+	for k := 0; k < n; k++ {
+		var v complex128
+		for r := 0; r < p; r++ {
+			v += sub[r][k%m] * twiddle(r*k, n, inverse)
+		}
+		y[k] = v
+	}
+	return y
+}
+
+// radix2 原地基 2 迭代 FFT，len(x) 必须是 2 的幂
+func radix2(x []complex128, inverse bool) {
+	n := len(x)
+	if n <= 1 {
+		return
+	}
+	shift := 64 - uint(bits.Len(uint(n-1)))
+	for i := 0; i < n; i++ {
+		j := int(bits.Reverse64(uint64(i)) >> shift)
+		if i < j {
+			x[i], x[j] = x[j], x[i]
+		}
+	}
+
+	for size := 2; size <= n; size <<= 1 {
+		half := size / 2
+		w := twiddle(1, size, inverse)
+		for start := 0; start < n; start += size {
+			wk := complex(1, 0)
+			for k := 0; k < half; k++ {
+				u := x[start+k]
+				v := x[start+k+half] * wk
+				x[start+k] = u + v
+				x[start+k+half] = u - v
+				wk *= w
+			}
+		}
+	}
+}
+
+// dft 直接计算离散傅里叶变换
+func dft(x []complex128, inverse bool) []complex128 {
+	n := len(x)
+	y := make([]complex128, n)
+	for k := 0; k < n; k++ {
+		var v complex128
+		for j := 0; j < n; j++ {
+			v += x[j] * twiddle(j*k%n, n, inverse)
+		}
+		y[k] = v
+	}
+	return y
+}
+
+// bluestein 将任意长度的 DFT 转换为 2 的幂长度的循环卷积
+func bluestein(x []complex128, inverse bool) []complex128 {
+	n := len(x)
+	m := 1
+	for m < 2*n-1 {
+		m <<= 1
+	}
+
+	// w[k] = exp(∓iπk²/n)，k² 对 2n 取模以保持精度
+	w := make([]complex128, n)
+	for k := 0; k < n; k++ {
+		w[k] = twiddle(k*k%(2*n), 2*n, inverse)
+	}
+
+	a := make([]complex128, m)
+	b := make([]complex128, m)
+	for k := 0; k < n; k++ {
+		a[k] = x[k] * w[k]
+	}
+	b[0] = cmplx.Conj(w[0])
+	for k := 1; k < n; k++ {
+		b[k] = cmplx.Conj(w[k])
+		b[m-k] = b[k]
+	}
+
+	radix2(a, false)
+	radix2(b, false)
+	for i := range a {
+		a[i] *= b[i]
+	}
+	radix2(a, true)
+
+	y := make([]complex128, n)
+	for k := 0; k < n; k++ {
+		y[k] = a[k] / complex(float64(m), 0) * w[k]
+	}
+	return y
+}
+
+// twiddle 旋转因子 exp(∓2πik/n)
+func twiddle(k, n int, inverse bool) complex128 {
+	theta := -2 * math.Pi * float64(k) / float64(n)
+	if inverse {
+		theta = -theta
+	}
+	return complex(math.Cos(theta), math.Sin(theta))
+}
+
+func smallestFactor(n int) int {
+	for p := 2; p*p <= n; p++ {
+		if n%p == 0 {
+			return p
+		}
+	}
+	return n
+}
+
+// ConvMode 卷积与相关的输出模式
+type ConvMode int
+
+const (
+	// ConvFull 完整输出，长度 m+n-1
+	ConvFull ConvMode = iota
+	// ConvSame 与较长序列等长的居中部分
+	ConvSame
+	// ConvValid 两序列完全重叠的部分，长度 max(m,n)-min(m,n)+1
+	ConvValid
+)
+
+// Convolve 按指定模式计算离散卷积
+func Convolve(F, G Matrix, mode ConvMode) Matrix {
+	f, g := convOperands("Convolve", F, G)
+	return convResult(F, G, convolve(f, g), len(f), len(g), mode)
+}
+
+// Correlate 按指定模式计算互相关 c[k] = sum_n F[n+k] * G[n]
+func Correlate(F, G Matrix, mode ConvMode) Matrix {
+	f, g := convOperands("Correlate", F, G)
+	for i, j := 0, len(g)-1; i < j; i, j = i+1, j-1 {
+		g[i], g[j] = g[j], g[i]
+	}
+	return convResult(F, G, convolve(f, g), len(f), len(g), mode)
+}
+
+// convOperands 校验卷积的两个向量方向一致并复制为切片
+func convOperands(name string, F, G Matrix) (f, g []float64) {
+	if !((F.Row == 1 && G.Row == 1) || (F.Col == 1 && G.Col == 1)) {
+		panic(fmt.Sprintf("%s only support vector", name))
+	}
+	f = make([]float64, F.Size())
+	for i := range f {
+		f[i] = F.GetIndex(i)
+	}
+	g = make([]float64, G.Size())
+	for i := range g {
+		g[i] = G.GetIndex(i)
+	}
+	return
+}
+
+// convResult 按输出模式截取完整卷积结果，两操作数都是行向量时结果为行向量，否则为列向量
+func convResult(F, G Matrix, y []float64, m, n int, mode ConvMode) Matrix {
+	short, long := m, n
+	if short > long {
+		short, long = long, short
+	}
+
+	switch mode {
+	case ConvFull:
+	case ConvSame:
+		start := (short - 1) / 2
+		y = y[start : start+long]
+	case ConvValid:
+		y = y[short-1 : long]
+	default:
+		panic(fmt.Sprintf("unknown conv mode %d", mode))
+	}
+
+	if F.Row == 1 && G.Row == 1 {
+		return NewVector(y, 2)
+	}
+	return NewVector(y, 1)
+}
+
+// convolve 完整离散卷积，序列较长时使用 FFT
+func convolve(f, g []float64) []float64 {
+	if len(f) == 0 || len(g) == 0 {
+		return []float64{}
+	}
+	l := len(f) + len(g) - 1
+	y := make([]float64, l)
+
+	if len(f) < convFFTThreshold || len(g) < convFFTThreshold {
+		for i := range f {
+			for j := range g {
+				y[i+j] += f[i] * g[j]
+			}
+		}
+		return y
+	}
+
+	n := 1
+	for n < l {
+		n <<= 1
+	}
+	a := make([]complex128, n)
+	b := make([]complex128, n)
+	for i, v := range f {
+		a[i] = complex(v, 0)
+	}
+	for i, v := range g {
+		b[i] = complex(v, 0)
+	}
+	radix2(a, false)
+	radix2(b, false)
+	for i := range a {
+		a[i] *= b[i]
+	}
+	radix2(a, true)
+	for i := range y {
+		y[i] = real(a[i]) / float64(n)
+	}
+	return y
+}
@@ -0,0 +1,124 @@
+package matrix
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func TestFFT(t *testing.T) {
+	for _, n := range []int{1, 2, 8, 12, 15, 37, 64, 97, 100} {
+		x := make([]complex128, n)
+		for i := range x {
+			x[i] = complex(math.Sin(float64(i)), math.Cos(float64(3*i)))
+		}
+
+		want := dft(x, false)
+		got := FFT(x)
+		back := IFFT(got)
+		for k := 0; k < n; k++ {
+			if cmplx.Abs(got[k]-want[k]) > 1e-9 {
+				t.Errorf("error method: FFT (n = %d)", n)
+				break
+			}
+			if cmplx.Abs(back[k]-x[k]) > 1e-9 {
+				t.Errorf("error method: IFFT (n = %d)", n)
+				break
+			}
+		}
+	}
+}
+
+func TestRFFT(t *testing.T) {
+	X := NewVector([]float64{1, 2, 0, -1, 3}, 2)
+	Y := RFFT(X)
+
+	if len(Y) != 3 || cmplx.Abs(Y[0]-5) > 1e-12 {
+		t.Error("error method: RFFT")
+	}
+	if !MatrixEqual(IRFFT(Y, 5), X) {
+		t.Error("error method: IRFFT")
+	}
+}
+
+func TestFFT2(t *testing.T) {
+	A := Builder().Row().Link(1, 2, 3).Link(4, 5, 6).Build()
+	X := FFT2(A)
+
+	if cmplx.Abs(X[0][0]-21) > 1e-12 || cmplx.Abs(X[1][0]+9) > 1e-12 {
+		t.Error("error method: FFT2")
+	}
+
+	Y := IFFT2(X)
+	for i := 0; i < A.Row; i++ {
+		for j := 0; j < A.Col; j++ {
+			if cmplx.Abs(Y[i][j]-complex(A.Get(i, j), 0)) > 1e-12 {
+				t.Error("error method: IFFT2")
+			}
+		}
+	}
+}
+
+func TestConvFFT(t *testing.T) {
+	n := 3 * convFFTThreshold
+	f := make([]float64, n)
+	g := make([]float64, n/2)
+	for i := range f {
+		f[i] = math.Sin(float64(i))
+	}
+	for i := range g {
+		g[i] = float64(i % 5)
+	}
+
+	Y := Conv(NewVector(f, 2), NewVector(g, 2))
+	if Y.Shape != (Shape{1, len(f) + len(g) - 1}) {
+		t.Error("error method: Conv shape")
+	}
+	for k := 0; k < Y.Size(); k++ {
+		v := 0.0
+		for j := range g {
+			if k-j >= 0 && k-j < len(f) {
+				v += f[k-j] * g[j]
+			}
+		}
+		if math.Abs(Y.GetIndex(k)-v) > 1e-9 {
+			t.Error("error method: Conv")
+			break
+		}
+	}
+}
+
+func TestConvOrientation(t *testing.T) {
+	// 1x1 与列向量卷积仍为列向量
+	F := Builder().Row().Link(2).Build()
+	G := Builder().Col().Link(1, 2, 3).Build()
+	if Y := Conv(F, G); !MatrixEqual(Y, Builder().Col().Link(2, 4, 6).Build()) {
+		t.Error("error method: Conv 1x1 by column")
+	}
+	if Y := Conv(G, F); !MatrixEqual(Y, Builder().Col().Link(2, 4, 6).Build()) {
+		t.Error("error method: Conv column by 1x1")
+	}
+	if Y := Conv(F, G.T()); !MatrixEqual(Y, Builder().Row().Link(2, 4, 6).Build()) {
+		t.Error("error method: Conv 1x1 by row")
+	}
+}
+
+func TestCorrelate(t *testing.T) {
+	F := NewVector([]float64{1, 2, 3}, 2)
+	G := NewVector([]float64{0, 1, 0.5}, 2)
+
+	full := NewVector([]float64{0.5, 2, 3.5, 3, 0}, 2)
+	same := NewVector([]float64{2, 3.5, 3}, 2)
+	valid := NewVector([]float64{3.5}, 2)
+
+	if !MatrixEqual(Correlate(F, G, ConvFull), full) ||
+		!MatrixEqual(Correlate(F, G, ConvSame), same) ||
+		!MatrixEqual(Correlate(F, G, ConvValid), valid) {
+		t.Error("error method: Correlate")
+	}
+
+	C := Convolve(NewVector([]float64{1, 2, 3}, 1), NewVector([]float64{0, 1, 0.5}, 1), ConvSame)
+	if !MatrixEqual(C, NewVector([]float64{1, 2.5, 4}, 1)) {
+		t.Error("error method: Convolve")
+	}
+}
@@ -0,0 +1,375 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+)
+
+// FilterType 滤波器类型
+type FilterType int
+
+const (
+	// Lowpass 低通
+	Lowpass FilterType = iota
+	// Highpass 高通
+	Highpass
+)
+
+// Window FIR 设计使用的窗函数
+type Window int
+
+const (
+	// Hamming 汉明窗
+	Hamming Window = iota
+	// Hann 汉宁窗
+	Hann
+	// Blackman 布莱克曼窗
+	Blackman
+	// Rectangular 矩形窗
+	Rectangular
+)
+
+// Filter 一维数字滤波 y = filter(b, a, x)，系数按降幂排列，与 Conv、Root 一致
+func Filter(B, A, X Matrix) (Y Matrix) {
+	Y, _ = FilterIC(B, A, X, Matrix{})
+	return
+}
+
+// FilterIC 带初始状态的数字滤波（直接 II 型转置结构）。
+// Zi 为长度 max(len(a), len(b))-1 的初始状态，零值矩阵表示零初始状态；返回输出与最终状态
+func FilterIC(B, A, X, Zi Matrix) (Y, Zf Matrix) {
+	if !IsVector(X) {
+		panic("Filter(b, a, x): x must be vector.")
+	}
+	b, a := filterCoeffs("Filter", B, A)
+	n := len(a)
+
+	z := make([]float64, n-1)
+	if Zi.Size() != 0 {
+		if Zi.Size() != n-1 {
+			panic(fmt.Sprintf("Filter(b, a, x, zi): len(zi) must be %d.", n-1))
+		}
+		for i := range z {
+			z[i] = Zi.GetIndex(i)
+		}
+	}
+
+	Y = Zeros(X.Shape)
+	for k := 0; k < X.Size(); k++ {
+		x := X.GetIndex(k)
+		y := b[0]*x + stateAt(z, 0)
+		for i := 0; i < n-1; i++ {
+			z[i] = b[i+1]*x + stateAt(z, i+1) - a[i+1]*y
+		}
+		Y.SetIndex(k, y)
+	}
+
+	Zf = NewVector(z, 1)
+	return
+}
+
+func stateAt(z []float64, i int) float64 {
+	if i < len(z) {
+		return z[i]
+	}
+	return 0
+}
+
+// filterCoeffs 将 b、a 补齐为等长并按 a[0] 归一化
+func filterCoeffs(name string, B, A Matrix) (b, a []float64) {
+	if !(IsVector(B) && IsVector(A)) {
+		panic(fmt.Sprintf("%s(b, a): b and a must be vector.", name))
+	}
+	if A.Size() == 0 || A.GetIndex(0) == 0 {
+		panic(fmt.Sprintf("%s(b, a): a[0] must not be zero.", name))
+	}
+
+	n := B.Size()
+	if A.Size() > n {
+		n = A.Size()
+	}
+	a0 := A.GetIndex(0)
+	b = make([]float64, n)
+	a = make([]float64, n)
+	for i := 0; i < B.Size(); i++ {
+		b[i] = B.GetIndex(i) / a0
+	}
+	for i := 0; i < A.Size(); i++ {
+		a[i] = A.GetIndex(i) / a0
+	}
+	return
+}
+
+// FilterZi 阶跃响应稳态对应的初始状态，乘以输入首值即可作为 FilterIC 的 Zi
+func FilterZi(B, A Matrix) Matrix {
+	b, a := filterCoeffs("FilterZi", B, A)
+	n := len(a) - 1
+	if n == 0 {
+		return Zeros(Shape{0, 1})
+	}
+
+	// (I - companion(a)^T) zi = b[1:] - a[1:]*b[0]
+	M := Eye(n)
+	R := Zeros(Shape{n, 1})
+	for i := 0; i < n; i++ {
+		M.Set(i, 0, M.Get(i, 0)+a[i+1])
+		if i+1 < n {
+			M.Set(i, i+1, M.Get(i, i+1)-1)
+		}
+		R.Set(i, 0, b[i+1]-a[i+1]*b[0])
+	}
+	return Solve(M, R)
+}
+
+// FiltFilt 零相位滤波：正向、反向各滤波一次，两端做奇对称延拓以减小边界瞬态，空信号返回同形状的空结果
+func FiltFilt(B, A, X Matrix) Matrix {
+	if !IsVector(X) {
+		panic("FiltFilt(b, a, x): x must be vector.")
+	}
+	b, _ := filterCoeffs("FiltFilt", B, A)
+	n := X.Size()
+	if n == 0 {
+		return Zeros(X.Shape)
+	}
+
+	pad := 3 * len(b)
+	if pad > n-1 {
+		pad = n - 1
+	}
+	if pad < 0 {
+		pad = 0
+	}
+
+	ext := make([]float64, 0, n+2*pad)
+	first, last := X.GetIndex(0), X.GetIndex(n-1)
+	for i := pad; i >= 1; i-- {
+		ext = append(ext, 2*first-X.GetIndex(i))
+	}
+	for i := 0; i < n; i++ {
+		ext = append(ext, X.GetIndex(i))
+	}
+	for i := n - 2; i >= n-1-pad; i-- {
+		ext = append(ext, 2*last-X.GetIndex(i))
+	}
+
+	zi := FilterZi(B, A)
+	E := NewVector(ext, 2)
+	Y, _ := FilterIC(B, A, E, zi.ScaleMul(ext[0]))
+
+	rev := reversed(Y)
+	Y, _ = FilterIC(B, A, rev, zi.ScaleMul(rev.GetIndex(0)))
+	Y = reversed(Y)
+
+	S := Zeros(X.Shape)
+	for i := 0; i < n; i++ {
+		S.SetIndex(i, Y.GetIndex(i+pad))
+	}
+	return S
+}
+
+// reversed 逆序向量
+func reversed(X Matrix) Matrix {
+	n := X.Size()
+	R := Zeros(X.Shape)
+	for i := 0; i < n; i++ {
+		R.SetIndex(i, X.GetIndex(n-1-i))
+	}
+	return R
+}
+
+// Freqz 数字滤波器的频率响应，在 [0, π) 上等间隔取 n 个频点，返回频率行向量与对应的复数响应
+func Freqz(B, A Matrix, n int) (W Matrix, H []complex128) {
+	if !(IsVector(B) && IsVector(A)) {
+		panic("Freqz(b, a): b and a must be vector.")
+	}
+
+	W = Zeros(Shape{1, n})
+	H = make([]complex128, n)
+	for k := 0; k < n; k++ {
+		w := math.Pi * float64(k) / float64(n)
+		W.SetIndex(k, w)
+
+		// 以 e^{-jw} 的幂次求值
+		z := cmplx.Exp(complex(0, -w))
+		H[k] = polyEvalAscending(B, z) / polyEvalAscending(A, z)
+	}
+	return
+}
+
+// polyEvalAscending 计算 c[0] + c[1]*z + c[2]*z^2 + ...
+func polyEvalAscending(C Matrix, z complex128) complex128 {
+	var v complex128
+	for i := C.Size() - 1; i >= 0; i-- {
+		v = v*z + complex(C.GetIndex(i), 0)
+	}
+	return v
+}
+
+// Butter 巴特沃斯 IIR 滤波器设计。n 为阶数，wn 为以奈奎斯特频率归一化的截止频率 (0, 1)
+func Butter(n int, wn float64, ftype FilterType) (B, A Matrix) {
+	if n < 1 {
+		panic("Butter(n, wn): n must >= 1.")
+	}
+	if wn <= 0 || wn >= 1 {
+		panic("Butter(n, wn): wn must be in (0, 1).")
+	}
+
+	// 模拟原型极点，采样频率取 2 时预畸变后的截止角频率
+	const fs2 = 4.0
+	wc := fs2 * math.Tan(math.Pi*wn/2)
+	poles := make([]complex128, n)
+	for k := 0; k < n; k++ {
+		theta := math.Pi * float64(2*k+n+1) / float64(2*n)
+		poles[k] = cmplx.Rect(1, theta)
+	}
+
+	var zeros []complex128
+	gain := complex(1, 0)
+	switch ftype {
+	case Lowpass:
+		for k := range poles {
+			poles[k] *= complex(wc, 0)
+		}
+		gain = complex(math.Pow(wc, float64(n)), 0)
+	case Highpass:
+		prod := complex(1, 0)
+		for k := range poles {
+			prod *= -poles[k]
+			poles[k] = complex(wc, 0) / poles[k]
+		}
+		gain = 1 / prod
+		zeros = make([]complex128, n)
+	default:
+		panic(fmt.Sprintf("Butter: unknown filter type %d.", ftype))
+	}
+
+	// 双线性变换 z = (fs2 + s) / (fs2 - s)，无穷远处的零点映射到 z = -1
+	num := complex(1, 0)
+	den := complex(1, 0)
+	dz := make([]complex128, n)
+	dp := make([]complex128, n)
+	for k := 0; k < n; k++ {
+		dp[k] = (fs2 + poles[k]) / (fs2 - poles[k])
+		den *= fs2 - poles[k]
+		if k < len(zeros) {
+			dz[k] = (fs2 + zeros[k]) / (fs2 - zeros[k])
+			num *= fs2 - zeros[k]
+		} else {
+			dz[k] = -1
+		}
+	}
+	gain = complex(real(gain*num/den), 0)
+
+	b := polyFromRoots(dz)
+	a := polyFromRoots(dp)
+	for i := range b {
+		b[i] *= real(gain)
+	}
+	return NewVector(b, 2), NewVector(a, 2)
+}
+
+// polyFromRoots 由根构造首一多项式系数（降幂），共轭成对的根保证结果为实数
+func polyFromRoots(roots []complex128) []float64 {
+	c := []complex128{1}
+	for _, r := range roots {
+		next := make([]complex128, len(c)+1)
+		for i, v := range c {
+			next[i] += v
+			next[i+1] -= v * r
+		}
+		c = next
+	}
+
+	p := make([]float64, len(c))
+	for i, v := range c {
+		p[i] = real(v)
+	}
+	return p
+}
+
+// FIR1 窗函数法设计 n 阶 FIR 滤波器，返回 n+1 个系数的 b 与 a = [1]。
+// 高通滤波器要求 n 为偶数
+func FIR1(n int, wn float64, ftype FilterType, window Window) (B, A Matrix) {
+	if n < 1 {
+		panic("FIR1(n, wn): n must >= 1.")
+	}
+	if wn <= 0 || wn >= 1 {
+		panic("FIR1(n, wn): wn must be in (0, 1).")
+	}
+	if ftype == Highpass && n%2 != 0 {
+		panic("FIR1(n, wn): highpass filter requires even order n.")
+	}
+
+	w := windowValues(window, n+1)
+	b := make([]float64, n+1)
+	mid := float64(n) / 2
+	for i := range b {
+		b[i] = wn * sinc(wn*(float64(i)-mid)) * w[i]
+	}
+
+	switch ftype {
+	case Lowpass:
+		// 直流增益归一化为 1
+		sum := 0.0
+		for _, v := range b {
+			sum += v
+		}
+		for i := range b {
+			b[i] /= sum
+		}
+	case Highpass:
+		// 全通减低通，奈奎斯特频率处增益归一化为 1
+		for i := range b {
+			b[i] = -b[i]
+		}
+		b[n/2] += w[n/2]
+		sum := 0.0
+		for i, v := range b {
+			if (i-n/2)%2 == 0 {
+				sum += v
+			} else {
+				sum -= v
+			}
+		}
+		for i := range b {
+			b[i] /= sum
+		}
+	default:
+		panic(fmt.Sprintf("FIR1: unknown filter type %d.", ftype))
+	}
+
+	return NewVector(b, 2), NewVector([]float64{1}, 2)
+}
+
+func sinc(x float64) float64 {
+	if x == 0 {
+		return 1
+	}
+	return math.Sin(math.Pi*x) / (math.Pi * x)
+}
+
+// windowValues 长度为 n 的对称窗
+func windowValues(window Window, n int) []float64 {
+	w := make([]float64, n)
+	for i := range w {
+		if n == 1 {
+			w[i] = 1
+			continue
+		}
+		t := 2 * math.Pi * float64(i) / float64(n-1)
+		switch window {
+		case Hamming:
+			w[i] = 0.54 - 0.46*math.Cos(t)
+		case Hann:
+			w[i] = 0.5 - 0.5*math.Cos(t)
+		case Blackman:
+			w[i] = 0.42 - 0.5*math.Cos(t) + 0.08*math.Cos(2*t)
+		case Rectangular:
+			w[i] = 1
+		default:
+			panic(fmt.Sprintf("unknown window %d.", window))
+		}
+	}
+	return w
+}
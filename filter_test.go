@@ -0,0 +1,129 @@
+package matrix
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func TestFilter(t *testing.T) {
+	B := NewVector([]float64{1}, 2)
+	A := NewVector([]float64{1, -0.5}, 2)
+	X := NewVector([]float64{1, 0, 0, 0}, 2)
+
+	if !MatrixEqual(Filter(B, A, X), NewVector([]float64{1, 0.5, 0.25, 0.125}, 2)) {
+		t.Error("error method: Filter")
+	}
+
+	// FIR 滤波等于截断后的卷积
+	B = NewVector([]float64{1, 2, 3}, 2)
+	X = NewVector([]float64{1, -1, 2, 0.5, 3}, 2)
+	Y := Filter(B, NewVector([]float64{1}, 2), X)
+	C := Conv(X, B)
+	for i := 0; i < X.Size(); i++ {
+		if math.Abs(Y.GetIndex(i)-C.GetIndex(i)) > 1e-12 {
+			t.Error("error method: Filter (FIR)")
+		}
+	}
+
+	// 分段滤波时传递状态，结果与整段滤波一致
+	B, A = Butter(3, 0.2, Lowpass)
+	Y = Filter(B, A, X)
+	Y1, Z := FilterIC(B, A, NewVector([]float64{1, -1}, 2), Matrix{})
+	Y2, _ := FilterIC(B, A, NewVector([]float64{2, 0.5, 3}, 2), Z)
+	for i := 0; i < 2; i++ {
+		if math.Abs(Y.GetIndex(i)-Y1.GetIndex(i)) > 1e-12 {
+			t.Error("error method: FilterIC")
+		}
+	}
+	for i := 0; i < 3; i++ {
+		if math.Abs(Y.GetIndex(i+2)-Y2.GetIndex(i)) > 1e-12 {
+			t.Error("error method: FilterIC")
+		}
+	}
+}
+
+func TestFiltFilt(t *testing.T) {
+	B, A := Butter(2, 0.3, Lowpass)
+
+	// 常数信号经过零相位低通滤波保持不变
+	X := Full(Shape{1, 20}, 3)
+	if !MatrixEqual(FiltFilt(B, A, X), X) {
+		t.Error("error method: FiltFilt")
+	}
+
+	// 低频正弦信号无相位延迟
+	n := 200
+	array := make([]float64, n)
+	for i := range array {
+		array[i] = math.Sin(2 * math.Pi * float64(i) / 100)
+	}
+	Y := FiltFilt(B, A, NewVector(array, 1))
+	for i := 50; i < 150; i++ {
+		if math.Abs(Y.GetIndex(i)-array[i]) > 1e-2 {
+			t.Error("error method: FiltFilt phase")
+			break
+		}
+	}
+
+	// 空信号返回同形状的空结果
+	for _, shape := range []Shape{{0, 1}, {1, 0}} {
+		if Y := FiltFilt(B, A, Zeros(shape)); Y.Shape != shape {
+			t.Errorf("error method: FiltFilt empty, got %v", Y.Shape)
+		}
+	}
+}
+
+func TestButter(t *testing.T) {
+	B, A := Butter(2, 0.5, Lowpass)
+	if !MatrixEqual(B, NewVector([]float64{0.2928932188134524, 0.5857864376269049, 0.2928932188134524}, 2)) ||
+		!MatrixEqual(A, NewVector([]float64{1, 0, 0.1715728752538099}, 2)) {
+		t.Error("error method: Butter")
+	}
+
+	for _, ftype := range []FilterType{Lowpass, Highpass} {
+		B, A = Butter(4, 0.3, ftype)
+		_, H := Freqz(B, A, 10)
+		h0 := cmplx.Abs(H[0])
+		hc := cmplx.Abs(H[3])
+		if ftype == Highpass {
+			h0 = 1 - h0
+		}
+		if math.Abs(h0-1) > 1e-9 || math.Abs(hc-math.Sqrt(0.5)) > 1e-9 {
+			t.Error("error method: Butter response")
+		}
+	}
+}
+
+func TestFIR1(t *testing.T) {
+	B, A := FIR1(20, 0.4, Lowpass, Hamming)
+	if B.Size() != 21 || A.Size() != 1 {
+		t.Error("error method: FIR1 size")
+	}
+	for i := 0; i < 10; i++ {
+		if math.Abs(B.GetIndex(i)-B.GetIndex(20-i)) > 1e-15 {
+			t.Error("error method: FIR1 symmetry")
+		}
+	}
+
+	_, H := Freqz(B, A, 100)
+	if math.Abs(cmplx.Abs(H[0])-1) > 1e-12 || cmplx.Abs(H[80]) > 1e-2 {
+		t.Error("error method: FIR1 lowpass")
+	}
+
+	B, A = FIR1(20, 0.4, Highpass, Hann)
+	_, H = Freqz(B, A, 100)
+	if cmplx.Abs(H[0]) > 1e-2 || math.Abs(cmplx.Abs(H[99])-1) > 1e-2 {
+		t.Error("error method: FIR1 highpass")
+	}
+}
+
+func TestSolve(t *testing.T) {
+	A := Builder().Row().Link(0, 1).Link(2, 1).Build()
+	B := Builder().Row().Link(1, 2).Link(3, 4).Build()
+	X := Solve(A, B)
+
+	if !MatrixEqual(A.Dot(X), B) {
+		t.Error("error method: Solve")
+	}
+}
@@ -0,0 +1,439 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+)
+
+// 定长向量与方阵，均为值类型，运算不分配堆内存，适合几何计算的内层循环。
+// 矩阵按行存储，M[i][j] 为第 i 行第 j 列
+
+// Vec2 二维向量
+type Vec2 [2]float64
+
+// Vec3 三维向量
+type Vec3 [3]float64
+
+// Vec4 四维向量
+type Vec4 [4]float64
+
+// Mat2 2x2 矩阵
+type Mat2 [2][2]float64
+
+// Mat3 3x3 矩阵
+type Mat3 [3][3]float64
+
+// Mat4 4x4 矩阵
+type Mat4 [4][4]float64
+
+// Add 向量相加
+func (v Vec2) Add(w Vec2) Vec2 { return Vec2{v[0] + w[0], v[1] + w[1]} }
+
+// Sub 向量相减
+func (v Vec2) Sub(w Vec2) Vec2 { return Vec2{v[0] - w[0], v[1] - w[1]} }
+
+// Scale 比例乘
+func (v Vec2) Scale(k float64) Vec2 { return Vec2{v[0] * k, v[1] * k} }
+
+// Dot 内积
+func (v Vec2) Dot(w Vec2) float64 { return v[0]*w[0] + v[1]*w[1] }
+
+// Cross 叉积的 z 分量
+func (v Vec2) Cross(w Vec2) float64 { return v[0]*w[1] - v[1]*w[0] }
+
+// Norm 2-范数
+func (v Vec2) Norm() float64 { return math.Hypot(v[0], v[1]) }
+
+// Normalize 单位化，零向量原样返回
+func (v Vec2) Normalize() Vec2 {
+	if n := v.Norm(); n != 0 {
+		return v.Scale(1 / n)
+	}
+	return v
+}
+
+// Matrix 转换为 2x1 列向量
+func (v Vec2) Matrix() Matrix { return NewVector([]float64{v[0], v[1]}, 1) }
+
+// Add 向量相加
+func (v Vec3) Add(w Vec3) Vec3 { return Vec3{v[0] + w[0], v[1] + w[1], v[2] + w[2]} }
+
+// Sub 向量相减
+func (v Vec3) Sub(w Vec3) Vec3 { return Vec3{v[0] - w[0], v[1] - w[1], v[2] - w[2]} }
+
+// Scale 比例乘
+func (v Vec3) Scale(k float64) Vec3 { return Vec3{v[0] * k, v[1] * k, v[2] * k} }
+
+// Dot 内积
+func (v Vec3) Dot(w Vec3) float64 { return v[0]*w[0] + v[1]*w[1] + v[2]*w[2] }
+
+// Cross 叉积
+func (v Vec3) Cross(w Vec3) Vec3 {
+	return Vec3{
+		v[1]*w[2] - v[2]*w[1],
+		v[2]*w[0] - v[0]*w[2],
+		v[0]*w[1] - v[1]*w[0],
+	}
+}
+
+// Norm 2-范数
+func (v Vec3) Norm() float64 { return math.Sqrt(v.Dot(v)) }
+
+// Normalize 单位化，零向量原样返回
+func (v Vec3) Normalize() Vec3 {
+	if n := v.Norm(); n != 0 {
+		return v.Scale(1 / n)
+	}
+	return v
+}
+
+// Matrix 转换为 3x1 列向量
+func (v Vec3) Matrix() Matrix { return NewVector([]float64{v[0], v[1], v[2]}, 1) }
+
+// Add 向量相加
+func (v Vec4) Add(w Vec4) Vec4 { return Vec4{v[0] + w[0], v[1] + w[1], v[2] + w[2], v[3] + w[3]} }
+
+// Sub 向量相减
+func (v Vec4) Sub(w Vec4) Vec4 { return Vec4{v[0] - w[0], v[1] - w[1], v[2] - w[2], v[3] - w[3]} }
+
+// Scale 比例乘
+func (v Vec4) Scale(k float64) Vec4 { return Vec4{v[0] * k, v[1] * k, v[2] * k, v[3] * k} }
+
+// Dot 内积
+func (v Vec4) Dot(w Vec4) float64 { return v[0]*w[0] + v[1]*w[1] + v[2]*w[2] + v[3]*w[3] }
+
+// Norm 2-范数
+func (v Vec4) Norm() float64 { return math.Sqrt(v.Dot(v)) }
+
+// Normalize 单位化，零向量原样返回
+func (v Vec4) Normalize() Vec4 {
+	if n := v.Norm(); n != 0 {
+		return v.Scale(1 / n)
+	}
+	return v
+}
+
+// Matrix 转换为 4x1 列向量
+func (v Vec4) Matrix() Matrix { return NewVector([]float64{v[0], v[1], v[2], v[3]}, 1) }
+
+// Ident2 2x2 单位矩阵
+func Ident2() Mat2 { return Mat2{{1, 0}, {0, 1}} }
+
+// Ident3 3x3 单位矩阵
+func Ident3() Mat3 { return Mat3{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}} }
+
+// Ident4 4x4 单位矩阵
+func Ident4() Mat4 { return Mat4{{1, 0, 0, 0}, {0, 1, 0, 0}, {0, 0, 1, 0}, {0, 0, 0, 1}} }
+
+// Add 矩阵相加
+func (M Mat2) Add(N Mat2) (S Mat2) {
+	for i := range S {
+		for j := range S[i] {
+			S[i][j] = M[i][j] + N[i][j]
+		}
+	}
+	return
+}
+
+// Scale 比例乘
+func (M Mat2) Scale(k float64) (S Mat2) {
+	for i := range S {
+		for j := range S[i] {
+			S[i][j] = M[i][j] * k
+		}
+	}
+	return
+}
+
+// Mul 矩阵乘法
+func (M Mat2) Mul(N Mat2) (S Mat2) {
+	for i := range S {
+		for j := range S[i] {
+			S[i][j] = M[i][0]*N[0][j] + M[i][1]*N[1][j]
+		}
+	}
+	return
+}
+
+// MulVec 矩阵乘列向量
+func (M Mat2) MulVec(v Vec2) Vec2 {
+	return Vec2{M[0][0]*v[0] + M[0][1]*v[1], M[1][0]*v[0] + M[1][1]*v[1]}
+}
+
+// Transpose 转置
+func (M Mat2) Transpose() Mat2 { return Mat2{{M[0][0], M[1][0]}, {M[0][1], M[1][1]}} }
+
+// Det 行列式
+func (M Mat2) Det() float64 { return M[0][0]*M[1][1] - M[0][1]*M[1][0] }
+
+// Inverse 逆矩阵，行列式为 0 时以 ErrSingular panic
+func (M Mat2) Inverse() Mat2 {
+	d := M.Det()
+	if d == 0 {
+		panic(ErrSingular)
+	}
+	return Mat2{{M[1][1], -M[0][1]}, {-M[1][0], M[0][0]}}.Scale(1 / d)
+}
+
+// Matrix 转换为 Matrix
+func (M Mat2) Matrix() Matrix {
+	return NewMatrix(Shape{2, 2}, []float64{M[0][0], M[0][1], M[1][0], M[1][1]})
+}
+
+// Add 矩阵相加
+func (M Mat3) Add(N Mat3) (S Mat3) {
+	for i := range S {
+		for j := range S[i] {
+			S[i][j] = M[i][j] + N[i][j]
+		}
+	}
+	return
+}
+
+// Scale 比例乘
+func (M Mat3) Scale(k float64) (S Mat3) {
+	for i := range S {
+		for j := range S[i] {
+			S[i][j] = M[i][j] * k
+		}
+	}
+	return
+}
+
+// Mul 矩阵乘法
+func (M Mat3) Mul(N Mat3) (S Mat3) {
+	for i := range S {
+		for j := range S[i] {
+			S[i][j] = M[i][0]*N[0][j] + M[i][1]*N[1][j] + M[i][2]*N[2][j]
+		}
+	}
+	return
+}
+
+// MulVec 矩阵乘列向量
+func (M Mat3) MulVec(v Vec3) (w Vec3) {
+	for i := range w {
+		w[i] = M[i][0]*v[0] + M[i][1]*v[1] + M[i][2]*v[2]
+	}
+	return
+}
+
+// Transpose 转置
+func (M Mat3) Transpose() (S Mat3) {
+	for i := range S {
+		for j := range S[i] {
+			S[i][j] = M[j][i]
+		}
+	}
+	return
+}
+
+// Det 行列式
+func (M Mat3) Det() float64 {
+	return M[0][0]*(M[1][1]*M[2][2]-M[1][2]*M[2][1]) -
+		M[0][1]*(M[1][0]*M[2][2]-M[1][2]*M[2][0]) +
+		M[0][2]*(M[1][0]*M[2][1]-M[1][1]*M[2][0])
+}
+
+// Inverse 伴随矩阵求逆，行列式为 0 时以 ErrSingular panic
+func (M Mat3) Inverse() Mat3 {
+	d := M.Det()
+	if d == 0 {
+		panic(ErrSingular)
+	}
+	// 各行为其余两行的叉积，即伴随矩阵的转置
+	r0, r1, r2 := Vec3(M[0]), Vec3(M[1]), Vec3(M[2])
+	return Mat3{r1.Cross(r2), r2.Cross(r0), r0.Cross(r1)}.Transpose().Scale(1 / d)
+}
+
+// Matrix 转换为 Matrix
+func (M Mat3) Matrix() Matrix {
+	A := Zeros(Shape{3, 3})
+	for i := range M {
+		for j := range M[i] {
+			A.Set(i, j, M[i][j])
+		}
+	}
+	return A
+}
+
+// Add 矩阵相加
+func (M Mat4) Add(N Mat4) (S Mat4) {
+	for i := range S {
+		for j := range S[i] {
+			S[i][j] = M[i][j] + N[i][j]
+		}
+	}
+	return
+}
+
+// Scale 比例乘
+func (M Mat4) Scale(k float64) (S Mat4) {
+	for i := range S {
+		for j := range S[i] {
+			S[i][j] = M[i][j] * k
+		}
+	}
+	return
+}
+
+// Mul 矩阵乘法
+func (M Mat4) Mul(N Mat4) (S Mat4) {
+	for i := range S {
+		for j := range S[i] {
+			S[i][j] = M[i][0]*N[0][j] + M[i][1]*N[1][j] + M[i][2]*N[2][j] + M[i][3]*N[3][j]
+		}
+	}
+	return
+}
+
+// MulVec 矩阵乘列向量
+func (M Mat4) MulVec(v Vec4) (w Vec4) {
+	for i := range w {
+		w[i] = M[i][0]*v[0] + M[i][1]*v[1] + M[i][2]*v[2] + M[i][3]*v[3]
+	}
+	return
+}
+
+// Transpose 转置
+func (M Mat4) Transpose() (S Mat4) {
+	for i := range S {
+		for j := range S[i] {
+			S[i][j] = M[j][i]
+		}
+	}
+	return
+}
+
+// minors 前两行与后两行的全部 2x2 子式，用于按 Laplace 展开求行列式与逆矩阵
+func (M Mat4) minors() (s, c [6]float64) {
+	s[0] = M[0][0]*M[1][1] - M[1][0]*M[0][1]
+	s[1] = M[0][0]*M[1][2] - M[1][0]*M[0][2]
+	s[2] = M[0][0]*M[1][3] - M[1][0]*M[0][3]
+	s[3] = M[0][1]*M[1][2] - M[1][1]*M[0][2]
+	s[4] = M[0][1]*M[1][3] - M[1][1]*M[0][3]
+	s[5] = M[0][2]*M[1][3] - M[1][2]*M[0][3]
+
+	c[5] = M[2][2]*M[3][3] - M[3][2]*M[2][3]
+	c[4] = M[2][1]*M[3][3] - M[3][1]*M[2][3]
+	c[3] = M[2][1]*M[3][2] - M[3][1]*M[2][2]
+	c[2] = M[2][0]*M[3][3] - M[3][0]*M[2][3]
+	c[1] = M[2][0]*M[3][2] - M[3][0]*M[2][2]
+	c[0] = M[2][0]*M[3][1] - M[3][0]*M[2][1]
+	return
+}
+
+// Det 行列式
+func (M Mat4) Det() float64 {
+	s, c := M.minors()
+	return s[0]*c[5] - s[1]*c[4] + s[2]*c[3] + s[3]*c[2] - s[4]*c[1] + s[5]*c[0]
+}
+
+// Inverse 伴随矩阵求逆，行列式为 0 时以 ErrSingular panic
+func (M Mat4) Inverse() Mat4 {
+	s, c := M.minors()
+	d := s[0]*c[5] - s[1]*c[4] + s[2]*c[3] + s[3]*c[2] - s[4]*c[1] + s[5]*c[0]
+	if d == 0 {
+		panic(ErrSingular)
+	}
+	k := 1 / d
+
+	var S Mat4
+	S[0][0] = (M[1][1]*c[5] - M[1][2]*c[4] + M[1][3]*c[3]) * k
+	S[0][1] = (-M[0][1]*c[5] + M[0][2]*c[4] - M[0][3]*c[3]) * k
+	S[0][2] = (M[3][1]*s[5] - M[3][2]*s[4] + M[3][3]*s[3]) * k
+	S[0][3] = (-M[2][1]*s[5] + M[2][2]*s[4] - M[2][3]*s[3]) * k
+
+	S[1][0] = (-M[1][0]*c[5] + M[1][2]*c[2] - M[1][3]*c[1]) * k
+	S[1][1] = (M[0][0]*c[5] - M[0][2]*c[2] + M[0][3]*c[1]) * k
+	S[1][2] = (-M[3][0]*s[5] + M[3][2]*s[2] - M[3][3]*s[1]) * k
+	S[1][3] = (M[2][0]*s[5] - M[2][2]*s[2] + M[2][3]*s[1]) * k
+
+	S[2][0] = (M[1][0]*c[4] - M[1][1]*c[2] + M[1][3]*c[0]) * k
+	S[2][1] = (-M[0][0]*c[4] + M[0][1]*c[2] - M[0][3]*c[0]) * k
+	S[2][2] = (M[3][0]*s[4] - M[3][1]*s[2] + M[3][3]*s[0]) * k
+	S[2][3] = (-M[2][0]*s[4] + M[2][1]*s[2] - M[2][3]*s[0]) * k
+
+	S[3][0] = (-M[1][0]*c[3] + M[1][1]*c[1] - M[1][2]*c[0]) * k
+	S[3][1] = (M[0][0]*c[3] - M[0][1]*c[1] + M[0][2]*c[0]) * k
+	S[3][2] = (-M[3][0]*s[3] + M[3][1]*s[1] - M[3][2]*s[0]) * k
+	S[3][3] = (M[2][0]*s[3] - M[2][1]*s[1] + M[2][2]*s[0]) * k
+	return S
+}
+
+// Matrix 转换为 Matrix
+func (M Mat4) Matrix() Matrix {
+	A := Zeros(Shape{4, 4})
+	for i := range M {
+		for j := range M[i] {
+			A.Set(i, j, M[i][j])
+		}
+	}
+	return A
+}
+
+// Vec2FromMatrix 由 2 个元素的行或列向量转换，元素个数不符时 panic
+func Vec2FromMatrix(A Matrix) (v Vec2) {
+	fixedElements(A, 2, "Vec2FromMatrix", v[:])
+	return
+}
+
+// Vec3FromMatrix 由 3 个元素的行或列向量转换，元素个数不符时 panic
+func Vec3FromMatrix(A Matrix) (v Vec3) {
+	fixedElements(A, 3, "Vec3FromMatrix", v[:])
+	return
+}
+
+// Vec4FromMatrix 由 4 个元素的行或列向量转换，元素个数不符时 panic
+func Vec4FromMatrix(A Matrix) (v Vec4) {
+	fixedElements(A, 4, "Vec4FromMatrix", v[:])
+	return
+}
+
+// Mat2FromMatrix 由 2x2 矩阵转换，形状不符时 panic
+func Mat2FromMatrix(A Matrix) (M Mat2) {
+	fixedShape(A, 2, "Mat2FromMatrix")
+	for i := range M {
+		for j := range M[i] {
+			M[i][j] = A.Get(i, j)
+		}
+	}
+	return
+}
+
+// Mat3FromMatrix 由 3x3 矩阵转换，形状不符时 panic
+func Mat3FromMatrix(A Matrix) (M Mat3) {
+	fixedShape(A, 3, "Mat3FromMatrix")
+	for i := range M {
+		for j := range M[i] {
+			M[i][j] = A.Get(i, j)
+		}
+	}
+	return
+}
+
+// Mat4FromMatrix 由 4x4 矩阵转换，形状不符时 panic
+func Mat4FromMatrix(A Matrix) (M Mat4) {
+	fixedShape(A, 4, "Mat4FromMatrix")
+	for i := range M {
+		for j := range M[i] {
+			M[i][j] = A.Get(i, j)
+		}
+	}
+	return
+}
+
+func fixedElements(A Matrix, n int, name string, dst []float64) {
+	if !IsVector(A) || A.Size() != n {
+		panic(fmt.Sprintf("%s(A): A must be a vector of %d elements, got %v.", name, n, A.Shape))
+	}
+	for i := range dst {
+		dst[i] = A.GetIndex(i)
+	}
+}
+
+func fixedShape(A Matrix, n int, name string) {
+	if A.Row != n || A.Col != n {
+		panic(fmt.Sprintf("%s(A): A must be %dx%d, got %v.", name, n, n, A.Shape))
+	}
+}
@@ -0,0 +1,123 @@
+package matrix
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestFixedVec(t *testing.T) {
+	a, b := Vec3{1, 2, 2}, Vec3{0, 1, 1}
+
+	if got := a.Dot(b); got != 4 {
+		t.Errorf("error method: Vec3.Dot, got %v, want 4", got)
+	}
+
+	if got := a.Norm(); got != 3 {
+		t.Errorf("error method: Vec3.Norm, got %v, want 3", got)
+	}
+
+	CExpected := Builder().Col().Link(0, -1, 1).Build()
+	if got := a.Cross(b).Matrix(); !MatrixEqual(CExpected, got) {
+		t.Errorf("error method: Vec3.Cross, got %v, want %v", got, CExpected)
+	}
+	if want, got := Cross(a.Matrix(), b.Matrix()), a.Cross(b).Matrix(); !MatrixEqual(want, got) {
+		t.Errorf("error method: Vec3.Cross, got %v, want %v", got, want)
+	}
+
+	if got := (Vec2{1, 0}).Cross(Vec2{0, 1}); got != 1 {
+		t.Errorf("error method: Vec2.Cross, got %v, want 1", got)
+	}
+
+	NExpected := Builder().Col().Link(0, 0.6, 0, 0.8).Build()
+	if got := (Vec4{0, 3, 0, 4}).Normalize().Matrix(); !MatrixEqual(NExpected, got) {
+		t.Errorf("error method: Vec4.Normalize, got %v, want %v", got, NExpected)
+	}
+
+	A := Builder().Row().Link(1, 2, 2).Build()
+	if got := Vec3FromMatrix(A); got != a {
+		t.Errorf("error method: Vec3FromMatrix, got %v, want %v", got, a)
+	}
+}
+
+func TestFixedMat2(t *testing.T) {
+	m := Mat2{{4, 7}, {2, 6}}
+
+	if got := m.Det(); got != 10 {
+		t.Errorf("error method: Mat2.Det, got %v, want 10", got)
+	}
+
+	if got := m.Mul(m.Inverse()).Matrix(); !MatrixEqual(Eye(2), got) {
+		t.Errorf("error method: Mat2.Inverse, M·M⁻¹ = %v, want I", got)
+	}
+}
+
+func TestFixedMat3(t *testing.T) {
+	A := Builder().Row().Link(2, 0, 1).Link(1, 3, 2).Link(1, 1, 2).Build()
+	m := Mat3FromMatrix(A)
+
+	if got := m.Det(); got != 6 {
+		t.Errorf("error method: Mat3.Det, got %v, want 6", got)
+	}
+
+	if want, got := Inv(A), m.Inverse().Matrix(); !MatrixEqual(want, got) {
+		t.Errorf("error method: Mat3.Inverse, got %v, want %v", got, want)
+	}
+
+	VExpected := Builder().Col().Link(3, 6, 4).Build()
+	if got := m.MulVec(Vec3{1, 1, 1}).Matrix(); !MatrixEqual(VExpected, got) {
+		t.Errorf("error method: Mat3.MulVec, got %v, want %v", got, VExpected)
+	}
+
+	if got := m.Transpose().Matrix(); !MatrixEqual(A.T(), got) {
+		t.Errorf("error method: Mat3.Transpose, got %v, want %v", got, A.T())
+	}
+
+	defer func() {
+		if err, _ := recover().(error); !errors.Is(err, ErrSingular) {
+			t.Errorf("error method: Mat3.Inverse singular, got %v, want ErrSingular", err)
+		}
+	}()
+	Mat3{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}}.Inverse()
+}
+
+func TestFixedMat4(t *testing.T) {
+	A := Builder().Row().
+		Link(1, 2, 0, 1).
+		Link(0, 1, 3, 2).
+		Link(4, 0, 1, 0).
+		Link(2, 1, 0, 3).
+		Build()
+	m := Mat4FromMatrix(A)
+
+	if got, want := m.Det(), Det(A); math.Abs(got-want) > 1e-9 {
+		t.Errorf("error method: Mat4.Det, got %v, want %v", got, want)
+	}
+
+	if want, got := Inv(A), m.Inverse().Matrix(); !MatrixEqual(want, got) {
+		t.Errorf("error method: Mat4.Inverse, got %v, want %v", got, want)
+	}
+
+	if want, got := A.Dot(A.T()), m.Mul(m.Transpose()).Matrix(); !MatrixEqual(want, got) {
+		t.Errorf("error method: Mat4.Mul, got %v, want %v", got, want)
+	}
+
+	if got := m.Matrix(); !MatrixEqual(A, got) {
+		t.Errorf("error method: Mat4FromMatrix, got %v, want %v", got, A)
+	}
+}
+
+func TestFixedNoAlloc(t *testing.T) {
+	m := Mat4{{1, 2, 0, 1}, {0, 1, 3, 2}, {4, 0, 1, 0}, {2, 1, 0, 3}}
+	v := Vec3{1, 2, 3}
+	var sink float64
+	n := testing.AllocsPerRun(100, func() {
+		w := v.Cross(Vec3{0, 1, 0}).Normalize()
+		n := m.Inverse().Mul(m.Transpose())
+		sink += w.Dot(v) + n.Det() + Mat3{{2, 0, 1}, {1, 3, 2}, {1, 1, 2}}.Inverse()[0][0]
+	})
+	if n != 0 {
+		t.Errorf("error method: fixed-size allocs, got %v, want 0", n)
+	}
+	_ = sink
+}
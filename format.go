@@ -0,0 +1,182 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// 多行格式（%+v）中行列数超过上限时只显示首尾各 prettyEdgeItems 项，中间以 ... 省略
+const (
+	prettyMaxRows   = 20
+	prettyMaxCols   = 12
+	prettyEdgeItems = 3
+)
+
+// String 以 [a, b; c, d] 形式输出，整数不带小数部分
+func (A Matrix) String() string {
+	return A.inline(formatDefault, 0, false)
+}
+
+// Format 实现 fmt.Formatter。
+//
+//	%v %s      与 String 相同；指定精度时按 %g 输出元素
+//	%f %e %g   每个元素按对应的浮点格式输出，支持宽度、精度及 + - 空格 0 标志
+//	%+v        多行对齐输出，过大的矩阵省略中间行列
+//	%#v        Go 源码形式
+func (A Matrix) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'v', 's':
+		if f.Flag('#') && verb == 'v' {
+			fmt.Fprint(f, A.goString())
+			return
+		}
+		elem := formatDefault
+		if prec, ok := f.Precision(); ok {
+			elem = func(v float64) string { return strconv.FormatFloat(v, 'g', prec, 64) }
+		}
+		width, _ := f.Width()
+		if f.Flag('+') && verb == 'v' {
+			fmt.Fprint(f, A.pretty(elem, f.Flag('-')))
+			return
+		}
+		fmt.Fprint(f, A.inline(elem, width, f.Flag('-')))
+	case 'f', 'F', 'e', 'E', 'g', 'G':
+		// 标志、宽度和精度原样作用于每个元素
+		format := "%"
+		for _, flag := range "-+ 0#" {
+			if f.Flag(int(flag)) {
+				format += string(flag)
+			}
+		}
+		if width, ok := f.Width(); ok {
+			format += strconv.Itoa(width)
+		}
+		if prec, ok := f.Precision(); ok {
+			format += "." + strconv.Itoa(prec)
+		}
+		format += string(verb)
+		fmt.Fprint(f, A.inline(func(v float64) string { return fmt.Sprintf(format, v) }, 0, false))
+	default:
+		fmt.Fprintf(f, "%%!%c(matrix.Matrix=%s)", verb, A.String())
+	}
+}
+
+// formatDefault 整数不带小数部分，其余按 %f 输出
+func formatDefault(v float64) string {
+	if r := math.Round(v); !math.IsInf(v, 0) && math.Abs(r) < 1e15 && math.Abs(v-r) < 1e-6 {
+		if r == 0 {
+			// 避免输出 -0
+			r = 0
+		}
+		return strconv.FormatFloat(r, 'f', 0, 64)
+	}
+	return fmt.Sprintf("%f", v)
+}
+
+// pad 按显示宽度补齐
+func pad(s string, width int, left bool) string {
+	n := utf8.RuneCountInString(s)
+	if n >= width {
+		return s
+	}
+	if left {
+		return s + strings.Repeat(" ", width-n)
+	}
+	return strings.Repeat(" ", width-n) + s
+}
+
+func (A Matrix) inline(elem func(float64) string, width int, left bool) string {
+	rows := make([]string, A.Row)
+	cols := make([]string, A.Col)
+	for i := 0; i < A.Row; i++ {
+		for j := 0; j < A.Col; j++ {
+			cols[j] = pad(elem(A.Get(i, j)), width, left)
+		}
+		rows[i] = strings.Join(cols, ", ")
+	}
+	return fmt.Sprintf("[%s]", strings.Join(rows, "; "))
+}
+
+// visible 返回需要显示的下标，-1 表示省略号
+func visible(n, max int) []int {
+	idx := make([]int, 0, n)
+	if n <= max {
+		for i := 0; i < n; i++ {
+			idx = append(idx, i)
+		}
+		return idx
+	}
+	for i := 0; i < prettyEdgeItems; i++ {
+		idx = append(idx, i)
+	}
+	idx = append(idx, -1)
+	for i := n - prettyEdgeItems; i < n; i++ {
+		idx = append(idx, i)
+	}
+	return idx
+}
+
+func (A Matrix) pretty(elem func(float64) string, left bool) string {
+	if A.Size() == 0 {
+		return "[]"
+	}
+	rows := visible(A.Row, prettyMaxRows)
+	cols := visible(A.Col, prettyMaxCols)
+
+	cells := make([][]string, len(rows))
+	widths := make([]int, len(cols))
+	for r, i := range rows {
+		cells[r] = make([]string, len(cols))
+		for c, j := range cols {
+			s := "..."
+			if i >= 0 && j >= 0 {
+				s = elem(A.Get(i, j))
+			} else if i < 0 && j >= 0 {
+				s = ":"
+			}
+			cells[r][c] = s
+			if n := utf8.RuneCountInString(s); n > widths[c] {
+				widths[c] = n
+			}
+		}
+	}
+
+	var sb strings.Builder
+	for r := range cells {
+		if r == 0 {
+			sb.WriteString("[ ")
+		} else {
+			sb.WriteString("\n  ")
+		}
+		for c, s := range cells[r] {
+			if c > 0 {
+				sb.WriteString("  ")
+			}
+			sb.WriteString(pad(s, widths[c], left))
+		}
+	}
+	sb.WriteString(" ]")
+	return sb.String()
+}
+
+func (A Matrix) goString() string {
+	values := make([]string, A.Size())
+	for i := range values {
+		v := A.GetIndex(i)
+		switch {
+		case math.IsNaN(v):
+			values[i] = "math.NaN()"
+		case math.IsInf(v, 1):
+			values[i] = "math.Inf(1)"
+		case math.IsInf(v, -1):
+			values[i] = "math.Inf(-1)"
+		default:
+			values[i] = strconv.FormatFloat(v, 'g', -1, 64)
+		}
+	}
+	return fmt.Sprintf("matrix.NewMatrix(matrix.Shape{Row: %d, Col: %d}, []float64{%s})",
+		A.Row, A.Col, strings.Join(values, ", "))
+}
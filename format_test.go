@@ -0,0 +1,62 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestString(t *testing.T) {
+	A := Builder().Row().Link(1, -0.5).Link(2.25, -3).Build()
+
+	if A.String() != "[1, -0.500000; 2.250000, -3]" {
+		t.Errorf("error method: String, got %s", A)
+	}
+	if s := fmt.Sprint(Diag([]float64{math.NaN(), math.Inf(1)})); s != "[NaN, 0; 0, +Inf]" {
+		t.Errorf("error method: String, got %s", s)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	A := Builder().Row().Link(1, -0.5).Link(2.25, 1000).Build()
+
+	cases := []struct {
+		format string
+		expect string
+	}{
+		{"%v", "[1, -0.500000; 2.250000, 1000]"},
+		{"%.3f", "[1.000, -0.500; 2.250, 1000.000]"},
+		{"%6.2f", "[  1.00,  -0.50;   2.25, 1000.00]"},
+		{"%-6.1f|", "[1.0   , -0.5  ; 2.2   , 1000.0]|"},
+		{"%+.1e", "[+1.0e+00, -5.0e-01; +2.2e+00, +1.0e+03]"},
+		{"%g", "[1, -0.5; 2.25, 1000]"},
+		{"%.2v", "[1, -0.5; 2.2, 1e+03]"},
+		{"%#v", "matrix.NewMatrix(matrix.Shape{Row: 2, Col: 2}, []float64{1, -0.5, 2.25, 1000})"},
+		{"%+v", "[        1  -0.500000\n  2.250000       1000 ]"},
+		{"%d", "%!d(matrix.Matrix=[1, -0.500000; 2.250000, 1000])"},
+	}
+	for _, c := range cases {
+		if s := fmt.Sprintf(c.format, A); s != c.expect {
+			t.Errorf("error method: Format(%q), got %q", c.format, s)
+		}
+	}
+}
+
+func TestFormatPretty(t *testing.T) {
+	A := Zeros(Shape{30, 30})
+	for i := 0; i < A.Size(); i++ {
+		A.SetIndex(i, float64(i))
+	}
+
+	lines := strings.Split(fmt.Sprintf("%+v", A), "\n")
+	if len(lines) != 2*prettyEdgeItems+1 {
+		t.Fatalf("error method: Format pretty rows, got %d lines", len(lines))
+	}
+	if lines[0] != "[   0    1    2  ...   27   28   29" || lines[prettyEdgeItems] != "    :    :    :  ...    :    :    :" {
+		t.Errorf("error method: Format pretty, got\n%s", strings.Join(lines, "\n"))
+	}
+	if !strings.HasSuffix(lines[len(lines)-1], "899 ]") {
+		t.Errorf("error method: Format pretty, got %q", lines[len(lines)-1])
+	}
+}
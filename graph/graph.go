@@ -0,0 +1,267 @@
+// Package graph 提供基于稠密邻接矩阵的图算法。
+//
+// 图以 n x n 的 matrix.Matrix 表示，A[i][j] 非 0 表示存在边 i → j，其值为边权。
+// 无向图应使用对称矩阵。邻接矩阵不是方阵时各函数 panic。
+//
+//	A := matrix.Builder().Row().Link(0, 1, 0).Link(1, 0, 1).Link(0, 1, 0).Build()
+//	order := graph.BFS(A, 0)        // [0 1 2]
+//	W := graph.CountPaths(A, 2)     // W[i][j] 为长度 2 的路径数
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/mrfyo/matrix"
+)
+
+// ErrNegativeCycle 图中存在负权回路，最短路径无定义
+var ErrNegativeCycle = errors.New("graph: negative cycle")
+
+func checkSquare(name string, A matrix.Matrix) int {
+	if A.Row != A.Col {
+		panic(fmt.Sprintf("%s(A): adjacency matrix must be square, got %v.", name, A.Shape))
+	}
+	return A.Row
+}
+
+func checkVertex(name string, n, s int) {
+	if s < 0 || s >= n {
+		panic(fmt.Sprintf("%s(A, %d): vertex out of range [0, %d).", name, s, n))
+	}
+}
+
+// BFS 从 s 出发广度优先遍历，返回可达顶点的访问顺序，邻居按编号升序访问
+func BFS(A matrix.Matrix, s int) []int {
+	n := checkSquare("BFS", A)
+	checkVertex("BFS", n, s)
+	seen := make([]bool, n)
+	seen[s] = true
+	order := []int{s}
+	for head := 0; head < len(order); head++ {
+		u := order[head]
+		for v := 0; v < n; v++ {
+			if A.Get(u, v) != 0 && !seen[v] {
+				seen[v] = true
+				order = append(order, v)
+			}
+		}
+	}
+	return order
+}
+
+// DFS 从 s 出发深度优先遍历，返回可达顶点的先序访问顺序，邻居按编号升序访问
+func DFS(A matrix.Matrix, s int) []int {
+	n := checkSquare("DFS", A)
+	checkVertex("DFS", n, s)
+	seen := make([]bool, n)
+	var order []int
+	var visit func(u int)
+	visit = func(u int) {
+		seen[u] = true
+		order = append(order, u)
+		for v := 0; v < n; v++ {
+			if A.Get(u, v) != 0 && !seen[v] {
+				visit(v)
+			}
+		}
+	}
+	visit(s)
+	return order
+}
+
+// Reachable 从 s 出发可达的顶点，s 自身总是可达
+func Reachable(A matrix.Matrix, s int) []bool {
+	n := checkSquare("Reachable", A)
+	reach := make([]bool, n)
+	for _, v := range BFS(A, s) {
+		reach[v] = true
+	}
+	return reach
+}
+
+// FloydWarshall 全源最短路径。D[i][j] 为 i 到 j 的最短距离，不可达为 +Inf，对角线为 0。
+// 存在负权回路时以 ErrNegativeCycle panic
+func FloydWarshall(A matrix.Matrix) matrix.Matrix {
+	n := checkSquare("FloydWarshall", A)
+	D := matrix.Full(A.Shape, math.Inf(1))
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if w := A.Get(i, j); w != 0 {
+				D.Set(i, j, w)
+			}
+		}
+		if D.Get(i, i) > 0 {
+			D.Set(i, i, 0)
+		}
+	}
+
+	for k := 0; k < n; k++ {
+		for i := 0; i < n; i++ {
+			dik := D.Get(i, k)
+			if math.IsInf(dik, 1) {
+				continue
+			}
+			for j := 0; j < n; j++ {
+				if d := dik + D.Get(k, j); d < D.Get(i, j) {
+					D.Set(i, j, d)
+				}
+			}
+		}
+	}
+	for i := 0; i < n; i++ {
+		if D.Get(i, i) < 0 {
+			panic(ErrNegativeCycle)
+		}
+	}
+	return D
+}
+
+// TransitiveClosure 传递闭包，R[i][j] 为 1 当且仅当存在 i 到 j 的长度至少为 1 的路径。
+// 以布尔矩阵乘法反复平方 R ← R ∨ R·R，至多 ⌈log₂ n⌉ + 1 次
+func TransitiveClosure(A matrix.Matrix) matrix.Matrix {
+	checkSquare("TransitiveClosure", A)
+	R := boolean(A)
+	for {
+		next := boolean(R.Add(R.Dot(R)))
+		if matrix.MatrixEqual(next, R) {
+			return R
+		}
+		R = next
+	}
+}
+
+// boolean 非 0 元素置 1
+func boolean(A matrix.Matrix) matrix.Matrix {
+	B := matrix.Zeros(A.Shape)
+	for i := 0; i < A.Row; i++ {
+		for j := 0; j < A.Col; j++ {
+			if A.Get(i, j) != 0 {
+				B.Set(i, j, 1)
+			}
+		}
+	}
+	return B
+}
+
+// Laplacian 图拉普拉斯矩阵 L = D - A，D 为各顶点出边权和构成的对角矩阵
+func Laplacian(A matrix.Matrix) matrix.Matrix {
+	n := checkSquare("Laplacian", A)
+	L := matrix.Zeros(A.Shape)
+	for i := 0; i < n; i++ {
+		deg := 0.0
+		for j := 0; j < n; j++ {
+			deg += A.Get(i, j)
+			L.Set(i, j, -A.Get(i, j))
+		}
+		L.Set(i, i, L.Get(i, i)+deg)
+	}
+	return L
+}
+
+// Fiedler 无向图拉普拉斯矩阵的第二小特征值（代数连通度）及其特征向量（n x 1）。
+// 特征向量第一个非零分量取正，A 不对称时 panic
+func Fiedler(A matrix.Matrix) (lambda float64, v matrix.Matrix) {
+	n := checkSquare("Fiedler", A)
+	if n < 2 {
+		panic(fmt.Sprintf("Fiedler(A): graph must have at least 2 vertices, got %d.", n))
+	}
+	if !matrix.MatrixEqual(A, A.T()) {
+		panic("Fiedler(A): adjacency matrix must be symmetric.")
+	}
+	D, V := matrix.EigSym(Laplacian(A))
+	// 特征值降序排列，第二小的位于倒数第二个
+	lambda = D.Get(0, n-2)
+	v = matrix.Zeros(matrix.Shape{Row: n, Col: 1})
+	sign := 0.0
+	for i := 0; i < n; i++ {
+		x := V.Get(i, n-2)
+		if sign == 0 && math.Abs(x) > 1e-12 {
+			sign = math.Copysign(1, x)
+		}
+		v.Set(i, 0, x)
+	}
+	if sign < 0 {
+		v = v.ScaleMul(-1)
+	}
+	return
+}
+
+// SpectralBisect 按 Fiedler 向量的符号将顶点分为两类，返回各顶点的类别 0 或 1
+func SpectralBisect(A matrix.Matrix) []int {
+	_, v := Fiedler(A)
+	labels := make([]int, v.Row)
+	for i := range labels {
+		if v.Get(i, 0) < 0 {
+			labels[i] = 1
+		}
+	}
+	return labels
+}
+
+// PageRank 幂迭代计算 PageRank，damping 通常取 0.85，返回 n x 1 列向量，各分量之和为 1。
+// 出边按边权分配，无出边的顶点均匀分配到所有顶点。1-范数变化小于 tol 或迭代 1000 次后停止
+func PageRank(A matrix.Matrix, damping, tol float64) matrix.Matrix {
+	n := checkSquare("PageRank", A)
+	if damping < 0 || damping > 1 {
+		panic(fmt.Sprintf("PageRank(A, %v, tol): damping must be in [0, 1].", damping))
+	}
+	out := make([]float64, n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			out[i] += A.Get(i, j)
+		}
+	}
+
+	r := make([]float64, n)
+	for i := range r {
+		r[i] = 1 / float64(n)
+	}
+	next := make([]float64, n)
+	for it := 0; it < 1000; it++ {
+		dangling := 0.0
+		for i := range next {
+			next[i] = 0
+			if out[i] == 0 {
+				dangling += r[i]
+			}
+		}
+		for i := 0; i < n; i++ {
+			if out[i] == 0 {
+				continue
+			}
+			for j := 0; j < n; j++ {
+				if w := A.Get(i, j); w != 0 {
+					next[j] += r[i] * w / out[i]
+				}
+			}
+		}
+		diff := 0.0
+		for j := range next {
+			next[j] = (1-damping)/float64(n) + damping*(next[j]+dangling/float64(n))
+			diff += math.Abs(next[j] - r[j])
+		}
+		r, next = next, r
+		if diff < tol {
+			break
+		}
+	}
+	return matrix.NewVector(r, 1)
+}
+
+// CountPaths 长度恰为 k 的路径（允许重复顶点）条数，即 0/1 邻接矩阵的 k 次幂，用反复平方计算
+func CountPaths(A matrix.Matrix, k int) matrix.Matrix {
+	n := checkSquare("CountPaths", A)
+	if k < 0 {
+		panic(fmt.Sprintf("CountPaths(A, %d): length must be non-negative.", k))
+	}
+	P, B := matrix.Eye(n), boolean(A)
+	for ; k > 0; k >>= 1 {
+		if k&1 == 1 {
+			P = P.Dot(B)
+		}
+		B = B.Dot(B)
+	}
+	return P
+}
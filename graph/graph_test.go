@@ -0,0 +1,171 @@
+package graph
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/mrfyo/matrix"
+)
+
+// path4 无向路径 0 - 1 - 2 - 3
+func path4() matrix.Matrix {
+	return matrix.Builder().Row().
+		Link(0, 1, 0, 0).
+		Link(1, 0, 1, 0).
+		Link(0, 1, 0, 1).
+		Link(0, 0, 1, 0).
+		Build()
+}
+
+func TestTraversal(t *testing.T) {
+	// 0 → 1, 0 → 2, 1 → 3, 2 → 3, 4 孤立
+	A := matrix.Builder().Row().
+		Link(0, 1, 1, 0, 0).
+		Link(0, 0, 0, 1, 0).
+		Link(0, 0, 0, 1, 0).
+		Link(0, 0, 0, 0, 0).
+		Link(0, 0, 0, 0, 0).
+		Build()
+
+	if got, want := BFS(A, 0), []int{0, 1, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("error method: BFS, got %v, want %v", got, want)
+	}
+
+	if got, want := DFS(A, 0), []int{0, 1, 3, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("error method: DFS, got %v, want %v", got, want)
+	}
+
+	if got, want := Reachable(A, 1), []bool{false, true, false, true, false}; !reflect.DeepEqual(got, want) {
+		t.Errorf("error method: Reachable, got %v, want %v", got, want)
+	}
+}
+
+func TestFloydWarshall(t *testing.T) {
+	A := matrix.Builder().Row().
+		Link(0, 3, 8, 0).
+		Link(0, 0, 0, 1).
+		Link(0, 4, 0, 0).
+		Link(2, 0, -5, 0).
+		Build()
+	DExpected := matrix.Builder().Row().
+		Link(0, 3, -1, 4).
+		Link(3, 0, -4, 1).
+		Link(7, 4, 0, 5).
+		Link(2, -1, -5, 0).
+		Build()
+
+	if got := FloydWarshall(A); !matrix.MatrixEqual(DExpected, got) {
+		t.Errorf("error method: FloydWarshall, got %v, want %v", got, DExpected)
+	}
+
+	// 删去 1 → 3 后 1 无法到达 0
+	A.Set(1, 3, 0)
+	if got := FloydWarshall(A).Get(1, 0); !math.IsInf(got, 1) {
+		t.Errorf("error method: FloydWarshall unreachable, got %v, want +Inf", got)
+	}
+
+	defer func() {
+		if err, _ := recover().(error); !errors.Is(err, ErrNegativeCycle) {
+			t.Errorf("error method: FloydWarshall negative cycle, got %v, want ErrNegativeCycle", err)
+		}
+	}()
+	FloydWarshall(matrix.Builder().Row().Link(0, 1).Link(-2, 0).Build())
+}
+
+func TestTransitiveClosure(t *testing.T) {
+	// 0 → 1 → 2 → 3 → 2
+	A := matrix.Builder().Row().
+		Link(0, 1, 0, 0).
+		Link(0, 0, 1, 0).
+		Link(0, 0, 0, 1).
+		Link(0, 0, 1, 0).
+		Build()
+	RExpected := matrix.Builder().Row().
+		Link(0, 1, 1, 1).
+		Link(0, 0, 1, 1).
+		Link(0, 0, 1, 1).
+		Link(0, 0, 1, 1).
+		Build()
+
+	if got := TransitiveClosure(A); !matrix.MatrixEqual(RExpected, got) {
+		t.Errorf("error method: TransitiveClosure, got %v, want %v", got, RExpected)
+	}
+}
+
+func TestCountPaths(t *testing.T) {
+	A := path4()
+
+	if got := CountPaths(A, 0); !matrix.MatrixEqual(matrix.Eye(4), got) {
+		t.Errorf("error method: CountPaths(A, 0), got %v, want I", got)
+	}
+
+	P := A
+	for k := 1; k <= 6; k++ {
+		if got := CountPaths(A, k); !matrix.MatrixEqual(P, got) {
+			t.Errorf("error method: CountPaths(A, %d), got %v, want %v", k, got, P)
+		}
+		P = P.Dot(A)
+	}
+
+	PExpected := matrix.Builder().Row().
+		Link(1, 0, 1, 0).
+		Link(0, 2, 0, 1).
+		Link(1, 0, 2, 0).
+		Link(0, 1, 0, 1).
+		Build()
+	if got := CountPaths(A, 2); !matrix.MatrixEqual(PExpected, got) {
+		t.Errorf("error method: CountPaths(A, 2), got %v, want %v", got, PExpected)
+	}
+}
+
+func TestSpectral(t *testing.T) {
+	LExpected := matrix.Builder().Row().
+		Link(1, -1, 0, 0).
+		Link(-1, 2, -1, 0).
+		Link(0, -1, 2, -1).
+		Link(0, 0, -1, 1).
+		Build()
+
+	if got := Laplacian(path4()); !matrix.MatrixEqual(LExpected, got) {
+		t.Errorf("error method: Laplacian, got %v, want %v", got, LExpected)
+	}
+
+	if lambda, _ := Fiedler(path4()); math.Abs(lambda-(2-math.Sqrt2)) > 1e-9 {
+		t.Errorf("error method: Fiedler, got %v, want %v", lambda, 2-math.Sqrt2)
+	}
+
+	// 两个三角形 {0, 1, 2} 与 {3, 4, 5} 由边 2 - 3 相连
+	A := matrix.Builder().Row().
+		Link(0, 1, 1, 0, 0, 0).
+		Link(1, 0, 1, 0, 0, 0).
+		Link(1, 1, 0, 1, 0, 0).
+		Link(0, 0, 1, 0, 1, 1).
+		Link(0, 0, 0, 1, 0, 1).
+		Link(0, 0, 0, 1, 1, 0).
+		Build()
+
+	if got, want := SpectralBisect(A), []int{0, 0, 0, 1, 1, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("error method: SpectralBisect, got %v, want %v", got, want)
+	}
+}
+
+func TestPageRank(t *testing.T) {
+	// 有向环上各顶点对称
+	C := matrix.Builder().Row().Link(0, 1, 0).Link(0, 0, 1).Link(1, 0, 0).Build()
+	RExpected := matrix.Builder().Col().Link(1.0/3, 1.0/3, 1.0/3).Build()
+
+	if got := PageRank(C, 0.85, 1e-12); !matrix.MatrixEqual(RExpected, got) {
+		t.Errorf("error method: PageRank, got %v, want %v", got, RExpected)
+	}
+
+	// 0 → 1，1 无出边：r0 = 0.075 + 0.425 r1，r0 + r1 = 1
+	A := matrix.Builder().Row().Link(0, 1).Link(0, 0).Build()
+	r0 := 0.5 / 1.425
+	RExpected = matrix.Builder().Col().Link(r0, 1-r0).Build()
+
+	if got := PageRank(A, 0.85, 1e-12); !matrix.MatrixEqual(RExpected, got) {
+		t.Errorf("error method: PageRank dangling, got %v, want %v", got, RExpected)
+	}
+}
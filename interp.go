@@ -0,0 +1,383 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Interpolator 一维插值器
+type Interpolator interface {
+	// Eval 插值点 x 处的函数值
+	Eval(x float64) float64
+	// Derivative 插值点 x 处的一阶导数
+	Derivative(x float64) float64
+}
+
+// Interp1 对向量 X 中的每个点求值，返回与 X 形状相同的矩阵
+func Interp1(I Interpolator, X Matrix) (Y Matrix) {
+	Y = Zeros(X.Shape)
+	for i := 0; i < X.Size(); i++ {
+		Y.SetIndex(i, I.Eval(X.GetIndex(i)))
+	}
+	return
+}
+
+// interpNodes 校验插值节点并复制为切片，x 必须严格递增
+func interpNodes(name string, X, Y Matrix, min int) (xs, ys []float64) {
+	if !(IsVector(X) && IsVector(Y)) {
+		panic(fmt.Sprintf("%s(X, Y): X and Y must be vector.", name))
+	}
+	if X.Size() != Y.Size() {
+		panic(fmt.Sprintf("%s(X, Y): size must equal.", name))
+	}
+	if X.Size() < min {
+		panic(fmt.Sprintf("%s(X, Y): need at least %d points.", name, min))
+	}
+
+	n := X.Size()
+	xs = make([]float64, n)
+	ys = make([]float64, n)
+	for i := 0; i < n; i++ {
+		xs[i] = X.GetIndex(i)
+		ys[i] = Y.GetIndex(i)
+		if i > 0 && xs[i] <= xs[i-1] {
+			panic(fmt.Sprintf("%s(X, Y): X must be strictly increasing.", name))
+		}
+	}
+	return
+}
+
+// interval 查找 x 所在区间 [xs[i], xs[i+1]]，区间外取首尾区间外推
+func interval(xs []float64, x float64) int {
+	i := sort.SearchFloat64s(xs, x) - 1
+	if i < 0 {
+		i = 0
+	}
+	if i > len(xs)-2 {
+		i = len(xs) - 2
+	}
+	return i
+}
+
+// LinearInterp 分段线性插值
+type LinearInterp struct {
+	x []float64
+	y []float64
+}
+
+// NewLinearInterp 分段线性插值
+func NewLinearInterp(X, Y Matrix) *LinearInterp {
+	xs, ys := interpNodes("NewLinearInterp", X, Y, 2)
+	return &LinearInterp{x: xs, y: ys}
+}
+
+func (p *LinearInterp) Eval(x float64) float64 {
+	i := interval(p.x, x)
+	t := (x - p.x[i]) / (p.x[i+1] - p.x[i])
+	return p.y[i] + t*(p.y[i+1]-p.y[i])
+}
+
+func (p *LinearInterp) Derivative(x float64) float64 {
+	i := interval(p.x, x)
+	return (p.y[i+1] - p.y[i]) / (p.x[i+1] - p.x[i])
+}
+
+// SplineBoundary 三次样条边界条件
+type SplineBoundary int
+
+const (
+	// NaturalSpline 自然边界：两端二阶导数为 0
+	NaturalSpline SplineBoundary = iota
+	// ClampedSpline 固定边界：指定两端一阶导数
+	ClampedSpline
+	// NotAKnotSpline 非扭结边界：首尾两段三阶导数连续
+	NotAKnotSpline
+)
+
+// CubicSpline 三次样条插值
+type CubicSpline struct {
+	x []float64
+	y []float64
+	m []float64 // 节点处的二阶导数
+}
+
+// NewCubicSpline 三次样条插值。
+// boundary 为 ClampedSpline 时 slopes 依次为左右端点的一阶导数，其余情况忽略
+func NewCubicSpline(X, Y Matrix, boundary SplineBoundary, slopes ...float64) *CubicSpline {
+	xs, ys := interpNodes("NewCubicSpline", X, Y, 2)
+	n := len(xs)
+
+	h := make([]float64, n-1)
+	d := make([]float64, n-1)
+	for i := 0; i < n-1; i++ {
+		h[i] = xs[i+1] - xs[i]
+		d[i] = (ys[i+1] - ys[i]) / h[i]
+	}
+
+	// 三对角方程组 a[i]*m[i-1] + b[i]*m[i] + c[i]*m[i+1] = r[i]
+	a := make([]float64, n)
+	b := make([]float64, n)
+	c := make([]float64, n)
+	r := make([]float64, n)
+	for i := 1; i < n-1; i++ {
+		a[i] = h[i-1]
+		b[i] = 2 * (h[i-1] + h[i])
+		c[i] = h[i]
+		r[i] = 6 * (d[i] - d[i-1])
+	}
+
+	switch boundary {
+	case NaturalSpline:
+		b[0], b[n-1] = 1, 1
+	case ClampedSpline:
+		if len(slopes) != 2 {
+			panic("NewCubicSpline: clamped spline requires two end slopes.")
+		}
+		b[0], c[0] = 2*h[0], h[0]
+		r[0] = 6 * (d[0] - slopes[0])
+		a[n-1], b[n-1] = h[n-2], 2*h[n-2]
+		r[n-1] = 6 * (slopes[1] - d[n-2])
+	case NotAKnotSpline:
+		if n < 4 {
+			// 不足四个点时非扭结样条退化为过所有点的多项式
+			m := 0.0
+			if n == 3 {
+				m = 2 * (d[1] - d[0]) / (xs[2] - xs[0])
+			}
+			ms := make([]float64, n)
+			for i := range ms {
+				ms[i] = m
+			}
+			return &CubicSpline{x: xs, y: ys, m: ms}
+		}
+		// 由 h1*m0 - (h0+h1)*m1 + h0*m2 = 0 消去 m0（末端同理），
+		// 对内部节点求解三对角方程组后再回代两端
+		b[1] += h[0] * (h[0] + h[1]) / h[1]
+		c[1] -= h[0] * h[0] / h[1]
+		b[n-2] += h[n-2] * (h[n-3] + h[n-2]) / h[n-3]
+		a[n-2] -= h[n-2] * h[n-2] / h[n-3]
+
+		inner := solveTridiagonal(a[1:n-1], b[1:n-1], c[1:n-1], r[1:n-1])
+		ms := make([]float64, n)
+		copy(ms[1:n-1], inner)
+		ms[0] = ((h[0]+h[1])*ms[1] - h[0]*ms[2]) / h[1]
+		ms[n-1] = ((h[n-3]+h[n-2])*ms[n-2] - h[n-2]*ms[n-3]) / h[n-3]
+		return &CubicSpline{x: xs, y: ys, m: ms}
+	default:
+		panic(fmt.Sprintf("NewCubicSpline: unknown boundary %d.", boundary))
+	}
+
+	return &CubicSpline{x: xs, y: ys, m: solveTridiagonal(a, b, c, r)}
+}
+
+func (s *CubicSpline) Eval(x float64) float64 {
+	i := interval(s.x, x)
+	h := s.x[i+1] - s.x[i]
+	u := s.x[i+1] - x
+	v := x - s.x[i]
+	return s.m[i]*u*u*u/(6*h) + s.m[i+1]*v*v*v/(6*h) +
+		(s.y[i]/h-s.m[i]*h/6)*u + (s.y[i+1]/h-s.m[i+1]*h/6)*v
+}
+
+func (s *CubicSpline) Derivative(x float64) float64 {
+	i := interval(s.x, x)
+	h := s.x[i+1] - s.x[i]
+	u := s.x[i+1] - x
+	v := x - s.x[i]
+	return -s.m[i]*u*u/(2*h) + s.m[i+1]*v*v/(2*h) -
+		(s.y[i]/h - s.m[i]*h/6) + (s.y[i+1]/h - s.m[i+1]*h/6)
+}
+
+// solveTridiagonal 追赶法求解三对角方程组，a 为下对角线（a[0] 无效），c 为上对角线（c[n-1] 无效）
+func solveTridiagonal(a, b, c, r []float64) []float64 {
+	n := len(b)
+	cp := make([]float64, n)
+	x := make([]float64, n)
+
+	w := b[0]
+	if w == 0 {
+		panic("solveTridiagonal: zero pivot.")
+	}
+	cp[0] = c[0] / w
+	x[0] = r[0] / w
+	for i := 1; i < n; i++ {
+		w = b[i] - a[i]*cp[i-1]
+		if w == 0 {
+			panic("solveTridiagonal: zero pivot.")
+		}
+		cp[i] = c[i] / w
+		x[i] = (r[i] - a[i]*x[i-1]) / w
+	}
+	for i := n - 2; i >= 0; i-- {
+		x[i] -= cp[i] * x[i+1]
+	}
+	return x
+}
+
+// PCHIP 分段三次 Hermite 保形插值（Fritsch-Carlson）
+type PCHIP struct {
+	x []float64
+	y []float64
+	s []float64 // 节点处的一阶导数
+}
+
+// NewPCHIP 分段三次 Hermite 保形插值
+func NewPCHIP(X, Y Matrix) *PCHIP {
+	xs, ys := interpNodes("NewPCHIP", X, Y, 2)
+	n := len(xs)
+
+	h := make([]float64, n-1)
+	d := make([]float64, n-1)
+	for i := 0; i < n-1; i++ {
+		h[i] = xs[i+1] - xs[i]
+		d[i] = (ys[i+1] - ys[i]) / h[i]
+	}
+
+	s := make([]float64, n)
+	if n == 2 {
+		s[0], s[1] = d[0], d[0]
+		return &PCHIP{x: xs, y: ys, s: s}
+	}
+
+	for i := 1; i < n-1; i++ {
+		if d[i-1]*d[i] <= 0 {
+			continue
+		}
+		// 加权调和平均
+		w1 := 2*h[i] + h[i-1]
+		w2 := h[i] + 2*h[i-1]
+		s[i] = (w1 + w2) / (w1/d[i-1] + w2/d[i])
+	}
+	s[0] = pchipEndSlope(h[0], h[1], d[0], d[1])
+	s[n-1] = pchipEndSlope(h[n-2], h[n-3], d[n-2], d[n-3])
+
+	return &PCHIP{x: xs, y: ys, s: s}
+}
+
+// pchipEndSlope 端点导数的三点公式，并保持单调
+func pchipEndSlope(h0, h1, d0, d1 float64) float64 {
+	s := ((2*h0+h1)*d0 - h0*d1) / (h0 + h1)
+	if s*d0 <= 0 {
+		return 0
+	}
+	if d0*d1 <= 0 && math.Abs(s) > math.Abs(3*d0) {
+		return 3 * d0
+	}
+	return s
+}
+
+func (p *PCHIP) Eval(x float64) float64 {
+	i := interval(p.x, x)
+	h := p.x[i+1] - p.x[i]
+	t := (x - p.x[i]) / h
+	t2 := t * t
+	t3 := t2 * t
+	return (2*t3-3*t2+1)*p.y[i] + (t3-2*t2+t)*h*p.s[i] +
+		(-2*t3+3*t2)*p.y[i+1] + (t3-t2)*h*p.s[i+1]
+}
+
+func (p *PCHIP) Derivative(x float64) float64 {
+	i := interval(p.x, x)
+	h := p.x[i+1] - p.x[i]
+	t := (x - p.x[i]) / h
+	t2 := t * t
+	return (6*t2-6*t)*p.y[i]/h + (3*t2-4*t+1)*p.s[i] +
+		(-6*t2+6*t)*p.y[i+1]/h + (3*t2-2*t)*p.s[i+1]
+}
+
+// Lagrange 拉格朗日插值多项式
+type Lagrange struct {
+	x []float64
+	y []float64
+}
+
+// NewLagrange 拉格朗日插值多项式
+func NewLagrange(X, Y Matrix) *Lagrange {
+	xs, ys := interpNodes("NewLagrange", X, Y, 1)
+	return &Lagrange{x: xs, y: ys}
+}
+
+func (l *Lagrange) Eval(x float64) float64 {
+	sum := 0.0
+	for i := range l.x {
+		w := 1.0
+		for j := range l.x {
+			if j != i {
+				w *= (x - l.x[j]) / (l.x[i] - l.x[j])
+			}
+		}
+		sum += w * l.y[i]
+	}
+	return sum
+}
+
+func (l *Lagrange) Derivative(x float64) float64 {
+	sum := 0.0
+	for i := range l.x {
+		// L_i'(x) = sum_k 1/(x_i-x_k) * prod_{j!=i,k} (x-x_j)/(x_i-x_j)
+		dw := 0.0
+		for k := range l.x {
+			if k == i {
+				continue
+			}
+			w := 1 / (l.x[i] - l.x[k])
+			for j := range l.x {
+				if j != i && j != k {
+					w *= (x - l.x[j]) / (l.x[i] - l.x[j])
+				}
+			}
+			dw += w
+		}
+		sum += dw * l.y[i]
+	}
+	return sum
+}
+
+// Newton 牛顿差商插值多项式
+type Newton struct {
+	x []float64
+	c []float64 // 差商系数 f[x0], f[x0,x1], ...
+}
+
+// NewNewton 牛顿差商插值多项式
+func NewNewton(X, Y Matrix) *Newton {
+	xs, ys := interpNodes("NewNewton", X, Y, 1)
+	n := len(xs)
+
+	c := make([]float64, n)
+	copy(c, ys)
+	for k := 1; k < n; k++ {
+		for i := n - 1; i >= k; i-- {
+			c[i] = (c[i] - c[i-1]) / (xs[i] - xs[i-k])
+		}
+	}
+	return &Newton{x: xs, c: c}
+}
+
+// Coefficients 差商系数行向量
+func (p *Newton) Coefficients() Matrix {
+	c := make([]float64, len(p.c))
+	copy(c, p.c)
+	return NewVector(c, 2)
+}
+
+func (p *Newton) Eval(x float64) float64 {
+	n := len(p.c)
+	v := p.c[n-1]
+	for k := n - 2; k >= 0; k-- {
+		v = v*(x-p.x[k]) + p.c[k]
+	}
+	return v
+}
+
+func (p *Newton) Derivative(x float64) float64 {
+	n := len(p.c)
+	v := p.c[n-1]
+	dv := 0.0
+	for k := n - 2; k >= 0; k-- {
+		dv = dv*(x-p.x[k]) + v
+		v = v*(x-p.x[k]) + p.c[k]
+	}
+	return dv
+}
@@ -0,0 +1,143 @@
+package matrix
+
+import "fmt"
+
+// Interp2D 二维网格插值，Z.Get(i, j) 为点 (x[j], y[i]) 处的函数值
+type Interp2D struct {
+	x     []float64
+	y     []float64
+	z     Matrix
+	cubic bool
+}
+
+// NewBilinear 二维双线性插值。X 长度等于 Z 的列数，Y 长度等于 Z 的行数
+func NewBilinear(X, Y, Z Matrix) *Interp2D {
+	xs, ys := gridNodes("NewBilinear", X, Y, Z, 2)
+	return &Interp2D{x: xs, y: ys, z: Z.Copy()}
+}
+
+// NewBicubic 二维双三次插值，网格节点处的偏导数由差分估计
+func NewBicubic(X, Y, Z Matrix) *Interp2D {
+	xs, ys := gridNodes("NewBicubic", X, Y, Z, 2)
+	return &Interp2D{x: xs, y: ys, z: Z.Copy(), cubic: true}
+}
+
+func gridNodes(name string, X, Y, Z Matrix, min int) (xs, ys []float64) {
+	if !(IsVector(X) && IsVector(Y)) {
+		panic(fmt.Sprintf("%s(X, Y, Z): X and Y must be vector.", name))
+	}
+	if X.Size() != Z.Col || Y.Size() != Z.Row {
+		panic(fmt.Sprintf("%s(X, Y, Z): grid size %d x %d not match Z %v.", name, Y.Size(), X.Size(), Z.Shape))
+	}
+	xs, _ = interpNodes(name, X, X, min)
+	ys, _ = interpNodes(name, Y, Y, min)
+	return
+}
+
+// Eval 点 (x, y) 处的插值
+func (p *Interp2D) Eval(x, y float64) float64 {
+	v, _, _ := p.eval(x, y)
+	return v
+}
+
+// Derivative 点 (x, y) 处的偏导数 (df/dx, df/dy)
+func (p *Interp2D) Derivative(x, y float64) (dx, dy float64) {
+	_, dx, dy = p.eval(x, y)
+	return
+}
+
+func (p *Interp2D) eval(x, y float64) (v, dx, dy float64) {
+	j := interval(p.x, x)
+	i := interval(p.y, y)
+	hx := p.x[j+1] - p.x[j]
+	hy := p.y[i+1] - p.y[i]
+	u := (x - p.x[j]) / hx
+	t := (y - p.y[i]) / hy
+
+	if !p.cubic {
+		z00 := p.z.Get(i, j)
+		z01 := p.z.Get(i, j+1)
+		z10 := p.z.Get(i+1, j)
+		z11 := p.z.Get(i+1, j+1)
+		v = z00*(1-u)*(1-t) + z01*u*(1-t) + z10*(1-u)*t + z11*u*t
+		dx = ((z01-z00)*(1-t) + (z11-z10)*t) / hx
+		dy = ((z10-z00)*(1-u) + (z11-z01)*u) / hy
+		return
+	}
+
+	// 单元四个角点的函数值及偏导数（按单位正方形缩放）
+	var f, fu, ft, fut [2][2]float64
+	for a := 0; a < 2; a++ {
+		for b := 0; b < 2; b++ {
+			f[a][b] = p.z.Get(i+a, j+b)
+			fu[a][b] = p.partialX(i+a, j+b) * hx
+			ft[a][b] = p.partialY(i+a, j+b) * hy
+			fut[a][b] = p.partialXY(i+a, j+b) * hx * hy
+		}
+	}
+
+	// Hermite 基函数：h 对应端点函数值，g 对应端点导数
+	hu, dhu, gu, dgu := hermiteBasis(u)
+	ht, dht, gt, dgt := hermiteBasis(t)
+
+	for a := 0; a < 2; a++ {
+		for b := 0; b < 2; b++ {
+			v += f[a][b]*hu[b]*ht[a] + fu[a][b]*gu[b]*ht[a] +
+				ft[a][b]*hu[b]*gt[a] + fut[a][b]*gu[b]*gt[a]
+			dx += f[a][b]*dhu[b]*ht[a] + fu[a][b]*dgu[b]*ht[a] +
+				ft[a][b]*dhu[b]*gt[a] + fut[a][b]*dgu[b]*gt[a]
+			dy += f[a][b]*hu[b]*dht[a] + fu[a][b]*gu[b]*dht[a] +
+				ft[a][b]*hu[b]*dgt[a] + fut[a][b]*gu[b]*dgt[a]
+		}
+	}
+	dx /= hx
+	dy /= hy
+	return
+}
+
+// hermiteBasis 单位区间上的三次 Hermite 基函数及其导数
+func hermiteBasis(t float64) (h, dh, g, dg [2]float64) {
+	t2 := t * t
+	t3 := t2 * t
+	h = [2]float64{2*t3 - 3*t2 + 1, -2*t3 + 3*t2}
+	dh = [2]float64{6*t2 - 6*t, -6*t2 + 6*t}
+	g = [2]float64{t3 - 2*t2 + t, t3 - t2}
+	dg = [2]float64{3*t2 - 4*t + 1, 3*t2 - 2*t}
+	return
+}
+
+// partialX 网格点处 df/dx 的差分估计
+func (p *Interp2D) partialX(i, j int) float64 {
+	l, r := j-1, j+1
+	if l < 0 {
+		l = 0
+	}
+	if r > len(p.x)-1 {
+		r = len(p.x) - 1
+	}
+	return (p.z.Get(i, r) - p.z.Get(i, l)) / (p.x[r] - p.x[l])
+}
+
+// partialY 网格点处 df/dy 的差分估计
+func (p *Interp2D) partialY(i, j int) float64 {
+	l, r := i-1, i+1
+	if l < 0 {
+		l = 0
+	}
+	if r > len(p.y)-1 {
+		r = len(p.y) - 1
+	}
+	return (p.z.Get(r, j) - p.z.Get(l, j)) / (p.y[r] - p.y[l])
+}
+
+// partialXY 网格点处 d2f/dxdy 的差分估计
+func (p *Interp2D) partialXY(i, j int) float64 {
+	l, r := i-1, i+1
+	if l < 0 {
+		l = 0
+	}
+	if r > len(p.y)-1 {
+		r = len(p.y) - 1
+	}
+	return (p.partialX(r, j) - p.partialX(l, j)) / (p.y[r] - p.y[l])
+}
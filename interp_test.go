@@ -0,0 +1,106 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLinearInterp(t *testing.T) {
+	X := NewVector([]float64{0, 1, 3}, 2)
+	Y := NewVector([]float64{0, 2, 0}, 2)
+	p := NewLinearInterp(X, Y)
+
+	if p.Eval(0.5) != 1 || p.Eval(2) != 1 || p.Derivative(2) != -1 {
+		t.Error("error method: LinearInterp")
+	}
+}
+
+func TestCubicSpline(t *testing.T) {
+	// 三次多项式可被非扭结样条和固定边界样条精确复现
+	f := func(x float64) float64 { return x*x*x - 2*x + 1 }
+	df := func(x float64) float64 { return 3*x*x - 2 }
+
+	xs := []float64{0, 0.5, 1.5, 2, 3}
+	ys := make([]float64, len(xs))
+	for i, x := range xs {
+		ys[i] = f(x)
+	}
+	X := NewVector(xs, 2)
+	Y := NewVector(ys, 2)
+
+	splines := []*CubicSpline{
+		NewCubicSpline(X, Y, NotAKnotSpline),
+		NewCubicSpline(X, Y, ClampedSpline, df(0), df(3)),
+	}
+	for _, s := range splines {
+		for _, x := range []float64{0.2, 1, 2.7} {
+			if math.Abs(s.Eval(x)-f(x)) > 1e-9 || math.Abs(s.Derivative(x)-df(x)) > 1e-9 {
+				t.Error("error method: CubicSpline")
+			}
+		}
+	}
+
+	s := NewCubicSpline(X, Y, NaturalSpline)
+	for i, x := range xs {
+		if math.Abs(s.Eval(x)-ys[i]) > 1e-12 {
+			t.Error("error method: CubicSpline")
+		}
+	}
+}
+
+func TestPCHIP(t *testing.T) {
+	X := NewVector([]float64{0, 1, 2, 3, 4}, 2)
+	Y := NewVector([]float64{0, 1, 1, 1, 5}, 2)
+	p := NewPCHIP(X, Y)
+
+	// 单调数据插值结果保持单调
+	prev := p.Eval(0)
+	for x := 0.05; x <= 4; x += 0.05 {
+		v := p.Eval(x)
+		if v < prev-1e-12 {
+			t.Error("error method: PCHIP")
+		}
+		prev = v
+	}
+	if p.Eval(1.5) != 1 {
+		t.Error("error method: PCHIP")
+	}
+}
+
+func TestLagrangeNewton(t *testing.T) {
+	X := NewVector([]float64{-1, 0, 1, 2}, 2)
+	Y := NewVector([]float64{-2, 1, 0, 1}, 2)
+
+	// f(x) = x^3 - 2x^2 + 1
+	l := NewLagrange(X, Y)
+	p := NewNewton(X, Y)
+	for _, x := range []float64{-0.5, 0.3, 1.7} {
+		v := x*x*x - 2*x*x + 1
+		dv := 3*x*x - 4*x
+		if math.Abs(l.Eval(x)-v) > 1e-12 || math.Abs(p.Eval(x)-v) > 1e-12 {
+			t.Error("error method: Lagrange/Newton Eval")
+		}
+		if math.Abs(l.Derivative(x)-dv) > 1e-12 || math.Abs(p.Derivative(x)-dv) > 1e-12 {
+			t.Error("error method: Lagrange/Newton Derivative")
+		}
+	}
+}
+
+func TestInterp2D(t *testing.T) {
+	// f(x, y) = 2x + 3y + xy 可被双线性插值精确复现
+	X := NewVector([]float64{0, 1, 2}, 2)
+	Y := NewVector([]float64{0, 2}, 2)
+	Z := Builder().Row().Link(0, 2, 4).Link(6, 10, 14).Build()
+
+	p := NewBilinear(X, Y, Z)
+	v := p.Eval(1.5, 0.5)
+	dx, dy := p.Derivative(1.5, 0.5)
+	if math.Abs(v-5.25) > 1e-12 || math.Abs(dx-2.5) > 1e-12 || math.Abs(dy-4.5) > 1e-12 {
+		t.Error("error method: Bilinear")
+	}
+
+	q := NewBicubic(X, Y, Z)
+	if math.Abs(q.Eval(1, 2)-10) > 1e-12 || math.Abs(q.Eval(1.5, 0.5)-5.25) > 1e-12 {
+		t.Error("error method: Bicubic")
+	}
+}
@@ -0,0 +1,383 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+)
+
+// IntMatrix 以 *big.Int 为元素的整数矩阵，行优先存储。
+// 所有运算都返回新矩阵，不修改参数，Get 返回元素的副本
+type IntMatrix struct {
+	Shape
+	array []*big.Int
+}
+
+// NewIntMatrix 默认构造方法
+func NewIntMatrix(shape Shape, array []int64) IntMatrix {
+	if len(array) != shape.Size() {
+		panic(fmt.Sprintf("NewIntMatrix: %d elements for shape %v.", len(array), shape))
+	}
+	A := IntZeros(shape)
+	for i, v := range array {
+		A.array[i].SetInt64(v)
+	}
+	return A
+}
+
+// IntZeros 零矩阵
+func IntZeros(shape Shape) (A IntMatrix) {
+	A.Shape = shape
+	A.array = make([]*big.Int, shape.Size())
+	for i := range A.array {
+		A.array[i] = new(big.Int)
+	}
+	return
+}
+
+// IntEye 单位矩阵
+func IntEye(n int) IntMatrix {
+	A := IntZeros(Shape{n, n})
+	for i := 0; i < n; i++ {
+		A.array[i*n+i].SetInt64(1)
+	}
+	return A
+}
+
+// IntFromMatrix 将整数值的浮点矩阵精确转换为整数矩阵，元素不是整数时 panic
+func IntFromMatrix(A Matrix) IntMatrix {
+	M := IntZeros(A.Shape)
+	for i := range M.array {
+		v := A.GetIndex(i)
+		if v != math.Trunc(v) || math.IsInf(v, 0) {
+			panic(fmt.Sprintf("IntFromMatrix(A): element %d is not an integer: %v.", i, v))
+		}
+		new(big.Float).SetFloat64(v).Int(M.array[i])
+	}
+	return M
+}
+
+// Matrix 转换为最接近的浮点矩阵
+func (A IntMatrix) Matrix() Matrix {
+	M := Zeros(A.Shape)
+	for i, v := range A.array {
+		f, _ := new(big.Float).SetInt(v).Float64()
+		M.SetIndex(i, f)
+	}
+	return M
+}
+
+// Get 获取元素的副本
+func (A IntMatrix) Get(i, j int) *big.Int {
+	return new(big.Int).Set(A.at(i, j))
+}
+
+// Set 设置元素，v 会被复制
+func (A IntMatrix) Set(i, j int, v *big.Int) {
+	A.at(i, j).Set(v)
+}
+
+func (A IntMatrix) at(i, j int) *big.Int {
+	if i >= A.Row || j >= A.Col || i < 0 || j < 0 {
+		panic(fmt.Sprintf("index out of bounds: (%d, %d) in %v", i, j, A.Shape))
+	}
+	return A.array[i*A.Col+j]
+}
+
+// Copy 深拷贝
+func (A IntMatrix) Copy() IntMatrix {
+	S := IntZeros(A.Shape)
+	for i, v := range A.array {
+		S.array[i].Set(v)
+	}
+	return S
+}
+
+// Equal 判断两矩阵是否相等
+func (A IntMatrix) Equal(B IntMatrix) bool {
+	if ShapeNotEqual(A.Shape, B.Shape) {
+		return false
+	}
+	for i, v := range A.array {
+		if v.Cmp(B.array[i]) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// String 以 [a, b; c, d] 形式输出
+func (A IntMatrix) String() string {
+	rows := make([]string, A.Row)
+	cells := make([]string, A.Col)
+	for i := 0; i < A.Row; i++ {
+		for j := 0; j < A.Col; j++ {
+			cells[j] = A.at(i, j).String()
+		}
+		rows[i] = strings.Join(cells, ", ")
+	}
+	return "[" + strings.Join(rows, "; ") + "]"
+}
+
+// Add 矩阵相加
+func (A IntMatrix) Add(B IntMatrix) (S IntMatrix) {
+	if ShapeNotEqual(A.Shape, B.Shape) {
+		panic(fmt.Sprintf("two matrix cannot [add]. %v x %v", A.Shape, B.Shape))
+	}
+	S = IntZeros(A.Shape)
+	for i := range S.array {
+		S.array[i].Add(A.array[i], B.array[i])
+	}
+	return
+}
+
+// Dot 矩阵乘法
+func (A IntMatrix) Dot(B IntMatrix) (S IntMatrix) {
+	if A.Col != B.Row {
+		panic(fmt.Sprintf("two matrix cannot [dot]. %v x %v", A.Shape, B.Shape))
+	}
+	S = IntZeros(Shape{A.Row, B.Col})
+	t := new(big.Int)
+	for i := 0; i < S.Row; i++ {
+		for j := 0; j < S.Col; j++ {
+			v := S.at(i, j)
+			for k := 0; k < A.Col; k++ {
+				v.Add(v, t.Mul(A.at(i, k), B.at(k, j)))
+			}
+		}
+	}
+	return
+}
+
+// T 转置
+func (A IntMatrix) T() (S IntMatrix) {
+	S = IntZeros(Shape{A.Col, A.Row})
+	for i := 0; i < A.Row; i++ {
+		for j := 0; j < A.Col; j++ {
+			S.at(j, i).Set(A.at(i, j))
+		}
+	}
+	return
+}
+
+// Det Bareiss 无分数消元求行列式，除法均为整除
+func (A IntMatrix) Det() *big.Int {
+	if A.Row != A.Col {
+		panic("Det(): matrix must be square.")
+	}
+	n := A.Row
+	M := A.Copy()
+	det := big.NewInt(1)
+	prev := big.NewInt(1)
+	t := new(big.Int)
+
+	for k := 0; k < n-1; k++ {
+		if M.at(k, k).Sign() == 0 {
+			p := k + 1
+			for p < n && M.at(p, k).Sign() == 0 {
+				p++
+			}
+			if p == n {
+				return new(big.Int)
+			}
+			M.swapRows(k, p)
+			det.Neg(det)
+		}
+		for i := k + 1; i < n; i++ {
+			for j := k + 1; j < n; j++ {
+				v := M.at(i, j)
+				v.Mul(v, M.at(k, k))
+				v.Sub(v, t.Mul(M.at(i, k), M.at(k, j)))
+				v.Quo(v, prev)
+			}
+		}
+		prev.Set(M.at(k, k))
+	}
+	if n > 0 {
+		det.Mul(det, M.at(n-1, n-1))
+	}
+	return det
+}
+
+// Hermite 行 Hermite 标准形，返回 H 与幺模矩阵 U，满足 U·A = H。
+// H 为行阶梯形，主元为正，主元上方的元素取 [0, 主元) 内的余数，零行在最后
+func (A IntMatrix) Hermite() (H, U IntMatrix) {
+	H, U = A.Copy(), IntEye(A.Row)
+	g, x, y := new(big.Int), new(big.Int), new(big.Int)
+	c, d := new(big.Int), new(big.Int)
+	q := new(big.Int)
+
+	r := 0
+	for j := 0; j < A.Col && r < A.Row; j++ {
+		// 用扩展欧几里得将第 j 列 r 行以下消为 0
+		for i := r + 1; i < A.Row; i++ {
+			b := H.at(i, j)
+			if b.Sign() == 0 {
+				continue
+			}
+			a := H.at(r, j)
+			g.GCD(x, y, a, b)
+			c.Quo(b, g).Neg(c)
+			d.Quo(a, g)
+			// [x y; -b/g a/g] 的行列式为 1
+			H.combineRows(r, i, x, y, c, d)
+			U.combineRows(r, i, x, y, c, d)
+		}
+		piv := H.at(r, j)
+		if piv.Sign() == 0 {
+			continue
+		}
+		if piv.Sign() < 0 {
+			H.negRow(r)
+			U.negRow(r)
+		}
+		for i := 0; i < r; i++ {
+			q.Div(H.at(i, j), piv)
+			if q.Sign() != 0 {
+				q.Neg(q)
+				H.addRow(i, r, q)
+				U.addRow(i, r, q)
+			}
+		}
+		r++
+	}
+	return
+}
+
+// Smith Smith 标准形，返回对角矩阵 D 与幺模矩阵 U、V，满足 U·A·V = D。
+// D 的对角元非负，且每个对角元整除下一个
+func (A IntMatrix) Smith() (D, U, V IntMatrix) {
+	D, U, V = A.Copy(), IntEye(A.Row), IntEye(A.Col)
+	q := new(big.Int)
+	n := A.Row
+	if A.Col < n {
+		n = A.Col
+	}
+
+	for t := 0; t < n; t++ {
+		for {
+			// 以剩余子矩阵中绝对值最小的非零元为主元
+			p, k := -1, -1
+			for i := t; i < A.Row; i++ {
+				for j := t; j < A.Col; j++ {
+					v := D.at(i, j)
+					if v.Sign() != 0 && (p < 0 || v.CmpAbs(D.at(p, k)) < 0) {
+						p, k = i, j
+					}
+				}
+			}
+			if p < 0 {
+				return
+			}
+			D.swapRows(t, p)
+			U.swapRows(t, p)
+			D.swapCols(t, k)
+			V.swapCols(t, k)
+
+			// 消去主元所在行列，余数非零时会出现更小的主元，重新选取
+			done := true
+			piv := D.at(t, t)
+			for i := t + 1; i < A.Row; i++ {
+				if D.at(i, t).Sign() == 0 {
+					continue
+				}
+				q.Quo(D.at(i, t), piv).Neg(q)
+				D.addRow(i, t, q)
+				U.addRow(i, t, q)
+				done = done && D.at(i, t).Sign() == 0
+			}
+			for j := t + 1; j < A.Col; j++ {
+				if D.at(t, j).Sign() == 0 {
+					continue
+				}
+				q.Quo(D.at(t, j), piv).Neg(q)
+				D.addCol(j, t, q)
+				V.addCol(j, t, q)
+				done = done && D.at(t, j).Sign() == 0
+			}
+			if !done {
+				continue
+			}
+
+			// 主元须整除剩余所有元素，否则把该行加到主元行后继续
+			r := new(big.Int)
+			for i := t + 1; i < A.Row && done; i++ {
+				for j := t + 1; j < A.Col; j++ {
+					if r.Rem(D.at(i, j), piv).Sign() != 0 {
+						one := big.NewInt(1)
+						D.addRow(t, i, one)
+						U.addRow(t, i, one)
+						done = false
+						break
+					}
+				}
+			}
+			if done {
+				break
+			}
+		}
+		if D.at(t, t).Sign() < 0 {
+			D.negRow(t)
+			U.negRow(t)
+		}
+	}
+	return
+}
+
+// combineRows 同时替换两行：R_i = a R_i + b R_j，R_j = c R_i + d R_j
+func (A IntMatrix) combineRows(i, j int, a, b, c, d *big.Int) {
+	s, t := new(big.Int), new(big.Int)
+	for k := 0; k < A.Col; k++ {
+		u, v := A.at(i, k), A.at(j, k)
+		s.Mul(a, u).Add(s, t.Mul(b, v))
+		t.Mul(c, u).Add(t, new(big.Int).Mul(d, v))
+		u.Set(s)
+		v.Set(t)
+	}
+}
+
+// addRow R_i += q R_j
+func (A IntMatrix) addRow(i, j int, q *big.Int) {
+	t := new(big.Int)
+	for k := 0; k < A.Col; k++ {
+		v := A.at(i, k)
+		v.Add(v, t.Mul(q, A.at(j, k)))
+	}
+}
+
+// addCol C_i += q C_j
+func (A IntMatrix) addCol(i, j int, q *big.Int) {
+	t := new(big.Int)
+	for k := 0; k < A.Row; k++ {
+		v := A.at(k, i)
+		v.Add(v, t.Mul(q, A.at(k, j)))
+	}
+}
+
+func (A IntMatrix) negRow(i int) {
+	for k := 0; k < A.Col; k++ {
+		v := A.at(i, k)
+		v.Neg(v)
+	}
+}
+
+// swapRows 原地交换两行
+func (A IntMatrix) swapRows(i, j int) {
+	if i == j {
+		return
+	}
+	for k := 0; k < A.Col; k++ {
+		A.array[i*A.Col+k], A.array[j*A.Col+k] = A.array[j*A.Col+k], A.array[i*A.Col+k]
+	}
+}
+
+// swapCols 原地交换两列
+func (A IntMatrix) swapCols(i, j int) {
+	if i == j {
+		return
+	}
+	for k := 0; k < A.Row; k++ {
+		A.array[k*A.Col+i], A.array[k*A.Col+j] = A.array[k*A.Col+j], A.array[k*A.Col+i]
+	}
+}
@@ -0,0 +1,152 @@
+package matrix
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func randInt(r *rand.Rand, shape Shape, max int64) IntMatrix {
+	array := make([]int64, shape.Size())
+	for i := range array {
+		array[i] = r.Int63n(2*max+1) - max
+	}
+	return NewIntMatrix(shape, array)
+}
+
+func isUnimodular(U IntMatrix) bool {
+	return U.Det().CmpAbs(big.NewInt(1)) == 0
+}
+
+// checkHermite 检查 U·A = H、U 幺模以及 H 满足 Hermite 标准形的条件
+func checkHermite(t *testing.T, A IntMatrix) {
+	t.Helper()
+	H, U := A.Hermite()
+	if !U.Dot(A).Equal(H) || !isUnimodular(U) {
+		t.Fatalf("Hermite(%v): U·A != H or U not unimodular\nH = %v\nU = %v", A, H, U)
+	}
+	last := -1
+	for i := 0; i < H.Row; i++ {
+		j := 0
+		for j < H.Col && H.at(i, j).Sign() == 0 {
+			j++
+		}
+		if j == H.Col {
+			last = H.Col
+			continue
+		}
+		if j <= last {
+			t.Fatalf("Hermite(%v): row %d is not in echelon form: %v", A, i, H)
+		}
+		last = j
+		piv := H.at(i, j)
+		if piv.Sign() <= 0 {
+			t.Fatalf("Hermite(%v): pivot %v is not positive", A, piv)
+		}
+		for k := 0; k < i; k++ {
+			if v := H.at(k, j); v.Sign() < 0 || v.Cmp(piv) >= 0 {
+				t.Fatalf("Hermite(%v): entry (%d, %d) = %v not reduced by pivot %v", A, k, j, v, piv)
+			}
+		}
+	}
+}
+
+// checkSmith 检查 U·A·V = D、U 与 V 幺模以及对角元的整除关系
+func checkSmith(t *testing.T, A IntMatrix) {
+	t.Helper()
+	D, U, V := A.Smith()
+	if !U.Dot(A).Dot(V).Equal(D) || !isUnimodular(U) || !isUnimodular(V) {
+		t.Fatalf("Smith(%v): U·A·V != D or transforms not unimodular\nD = %v", A, D)
+	}
+	r := new(big.Int)
+	for i := 0; i < D.Row; i++ {
+		for j := 0; j < D.Col; j++ {
+			if v := D.at(i, j); i != j && v.Sign() != 0 || i == j && v.Sign() < 0 {
+				t.Fatalf("Smith(%v): D is not a non-negative diagonal: %v", A, D)
+			}
+		}
+		if i+1 < D.Row && i+1 < D.Col {
+			d, next := D.at(i, i), D.at(i+1, i+1)
+			if d.Sign() == 0 && next.Sign() != 0 || d.Sign() != 0 && r.Rem(next, d).Sign() != 0 {
+				t.Fatalf("Smith(%v): %v does not divide %v", A, d, next)
+			}
+		}
+	}
+}
+
+func TestHermite(t *testing.T) {
+	A := NewIntMatrix(Shape{3, 4}, []int64{
+		2, 3, 6, 2,
+		5, 6, 1, 6,
+		8, 3, 1, 1,
+	})
+	H, _ := A.Hermite()
+	want := NewIntMatrix(Shape{3, 4}, []int64{
+		1, 0, 50, -11,
+		0, 3, 28, -2,
+		0, 0, 61, -13,
+	})
+	if !H.Equal(want) {
+		t.Errorf("Hermite(A) = %v, want %v", H, want)
+	}
+	checkHermite(t, A)
+
+	r := rand.New(rand.NewSource(1))
+	for _, shape := range []Shape{{1, 1}, {3, 3}, {4, 6}, {6, 4}, {5, 5}} {
+		checkHermite(t, randInt(r, shape, 20))
+	}
+	// 秩亏与空矩阵
+	checkHermite(t, randInt(r, Shape{4, 2}, 9).Dot(randInt(r, Shape{2, 5}, 9)))
+	checkHermite(t, NewIntMatrix(Shape{0, 0}, nil))
+}
+
+func TestSmith(t *testing.T) {
+	A := NewIntMatrix(Shape{3, 3}, []int64{
+		2, 4, 4,
+		-6, 6, 12,
+		10, -4, -16,
+	})
+	D, _, _ := A.Smith()
+	if want := NewIntMatrix(Shape{3, 3}, []int64{2, 0, 0, 0, 6, 0, 0, 0, 12}); !D.Equal(want) {
+		t.Errorf("Smith(A) = %v, want %v", D, want)
+	}
+	checkSmith(t, A)
+
+	r := rand.New(rand.NewSource(2))
+	for _, shape := range []Shape{{1, 3}, {3, 1}, {4, 4}, {3, 5}, {5, 3}, {6, 6}} {
+		checkSmith(t, randInt(r, shape, 30))
+	}
+	// 秩亏与零矩阵
+	B := randInt(r, Shape{4, 2}, 9).Dot(randInt(r, Shape{2, 5}, 9))
+	checkSmith(t, B)
+	checkSmith(t, IntZeros(Shape{2, 3}))
+	D, _, _ = B.Smith()
+	if D.at(2, 2).Sign() != 0 || D.at(1, 1).Sign() == 0 {
+		t.Errorf("Smith of rank-2 matrix: %v", D)
+	}
+}
+
+func TestIntMatrixBig(t *testing.T) {
+	// 元素超过 64 位，检查没有溢出
+	A := IntZeros(Shape{3, 3})
+	base := new(big.Int).Lsh(big.NewInt(1), 80)
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			v := new(big.Int).Mul(base, big.NewInt(int64(i*3+j+1)))
+			A.Set(i, j, v.Add(v, big.NewInt(int64(i*i+j))))
+		}
+	}
+	checkHermite(t, A)
+	checkSmith(t, A)
+
+	if got := NewIntMatrix(Shape{2, 2}, []int64{3, 7, 1, -4}).Det(); got.Int64() != -19 {
+		t.Errorf("Det = %v, want -19", got)
+	}
+	M := Builder().Row().Link(1, -2).Link(3e20, 4).Build()
+	if I := IntFromMatrix(M); I.at(1, 0).String() != "300000000000000000000" || !MatrixEqual(I.Matrix(), M) {
+		t.Errorf("IntFromMatrix = %v", I)
+	}
+	if s := NewIntMatrix(Shape{1, 2}, []int64{1, -2}).T().String(); s != "[1; -2]" {
+		t.Errorf("String() = %s", s)
+	}
+}
@@ -0,0 +1,134 @@
+package matrix
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// JSONForm Matrix 的 JSON 格式
+type JSONForm int
+
+const (
+	// JSONNested 嵌套数组 [[1,2],[3,4]]
+	JSONNested JSONForm = iota
+	// JSONFlat 形状加行优先数据 {"shape":[2,2],"data":[1,2,3,4]}
+	JSONFlat
+)
+
+// jsonFlat JSONFlat 格式
+type jsonFlat struct {
+	Shape *Shape    `json:"shape"`
+	Data  []float64 `json:"data"`
+}
+
+// MarshalJSON 序列化为 JSONNested 格式，需要其他格式时使用 MarshalJSONForm
+func (A Matrix) MarshalJSON() ([]byte, error) {
+	return MarshalJSONForm(A, JSONNested)
+}
+
+// MarshalJSONForm 按指定格式序列化矩阵，反序列化时两种格式均可识别。
+// 嵌套数组无法表示 0 x n 矩阵的列数，这类矩阵总是使用 JSONFlat 格式
+func MarshalJSONForm(A Matrix, form JSONForm) ([]byte, error) {
+	if form == JSONNested && A.Row == 0 && A.Col != 0 {
+		form = JSONFlat
+	}
+	switch form {
+	case JSONNested:
+		rows := make([][]float64, A.Row)
+		for i := 0; i < A.Row; i++ {
+			rows[i] = make([]float64, A.Col)
+			for j := 0; j < A.Col; j++ {
+				rows[i][j] = A.Get(i, j)
+			}
+		}
+		return json.Marshal(rows)
+	case JSONFlat:
+		data := make([]float64, A.Size())
+		for i := range data {
+			data[i] = A.GetIndex(i)
+		}
+		return json.Marshal(jsonFlat{Shape: &A.Shape, Data: data})
+	default:
+		return nil, fmt.Errorf("matrix: unknown json form %d", form)
+	}
+}
+
+// UnmarshalJSON 解析嵌套数组或 {"shape", "data"} 格式
+func (A *Matrix) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+	if bytes.Equal(b, []byte("null")) {
+		return nil
+	}
+	if len(b) == 0 {
+		return fmt.Errorf("matrix: empty json input")
+	}
+
+	if b[0] == '{' {
+		var f jsonFlat
+		if err := json.Unmarshal(b, &f); err != nil {
+			return err
+		}
+		if f.Shape == nil {
+			return fmt.Errorf("matrix: json object missing \"shape\"")
+		}
+		if !decodeShapeOK(uint64(f.Shape.Row), uint64(f.Shape.Col)) {
+			return fmt.Errorf("matrix: shape %v too large", *f.Shape)
+		}
+		if len(f.Data) != f.Shape.Size() {
+			return fmt.Errorf("matrix: data length %d not match shape %v", len(f.Data), *f.Shape)
+		}
+		*A = NewMatrix(*f.Shape, f.Data)
+		return nil
+	}
+
+	var rows [][]float64
+	if err := json.Unmarshal(b, &rows); err != nil {
+		return err
+	}
+	shape := Shape{Row: len(rows)}
+	if len(rows) > 0 {
+		shape.Col = len(rows[0])
+	}
+	array := make([]float64, 0, shape.Size())
+	for i, row := range rows {
+		if len(row) != shape.Col {
+			return fmt.Errorf("matrix: row %d has %d elements, expect %d", i, len(row), shape.Col)
+		}
+		array = append(array, row...)
+	}
+	*A = NewMatrix(shape, array)
+	return nil
+}
+
+// MarshalJSON 序列化为 [Row, Col]
+func (s Shape) MarshalJSON() ([]byte, error) {
+	return json.Marshal([2]int{s.Row, s.Col})
+}
+
+// UnmarshalJSON 解析 [Row, Col]，兼容 {"Row": 2, "Col": 2}，维度不能为负
+func (s *Shape) UnmarshalJSON(b []byte) error {
+	var shape Shape
+	b = bytes.TrimSpace(b)
+	if len(b) > 0 && b[0] == '{' {
+		var v struct{ Row, Col int }
+		if err := json.Unmarshal(b, &v); err != nil {
+			return err
+		}
+		shape = Shape{Row: v.Row, Col: v.Col}
+	} else {
+		var v []int
+		if err := json.Unmarshal(b, &v); err != nil {
+			return err
+		}
+		if len(v) != 2 {
+			return fmt.Errorf("matrix: shape must have 2 dimensions, got %d", len(v))
+		}
+		shape = Shape{Row: v[0], Col: v[1]}
+	}
+	if shape.Row < 0 || shape.Col < 0 {
+		return fmt.Errorf("matrix: invalid shape %v", shape)
+	}
+	*s = shape
+	return nil
+}
@@ -0,0 +1,82 @@
+package matrix
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	A := Builder().Row().Link(1, 2).Link(3, 4.5).Build()
+
+	b, err := json.Marshal(A)
+	if err != nil || string(b) != "[[1,2],[3,4.5]]" {
+		t.Errorf("error method: MarshalJSON, got %s", b)
+	}
+
+	b, err = MarshalJSONForm(A, JSONFlat)
+	if err != nil || string(b) != `{"shape":[2,2],"data":[1,2,3,4.5]}` {
+		t.Errorf("error method: MarshalJSONForm, got %s", b)
+	}
+
+	var payload struct {
+		Name string
+		M    Matrix
+	}
+	payload.M = A
+	b, _ = json.Marshal(payload)
+	if string(b) != `{"Name":"","M":[[1,2],[3,4.5]]}` {
+		t.Errorf("error method: MarshalJSON in struct, got %s", b)
+	}
+
+	// 0 x n 矩阵改用 JSONFlat 格式以保留列数
+	for _, shape := range []Shape{{0, 3}, {2, 0}, {0, 0}} {
+		var B Matrix
+		b, err := json.Marshal(Zeros(shape))
+		if err != nil || json.Unmarshal(b, &B) != nil || B.Shape != shape {
+			t.Errorf("error method: MarshalJSON %v, got %s, decoded %v", shape, b, B.Shape)
+		}
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	Expected := Builder().Row().Link(1, 2, 3).Link(4, 5, 6).Build()
+
+	var A, B Matrix
+	if err := json.Unmarshal([]byte("[[1,2,3],[4,5,6]]"), &A); err != nil || !MatrixEqual(A, Expected) {
+		t.Error("error method: UnmarshalJSON nested")
+	}
+	if err := json.Unmarshal([]byte(`{"shape":[2,3],"data":[1,2,3,4,5,6]}`), &B); err != nil || !MatrixEqual(B, Expected) {
+		t.Error("error method: UnmarshalJSON flat")
+	}
+
+	bad := []string{
+		`[[1,2],[3]]`,
+		`{"shape":[2,2],"data":[1,2,3]}`,
+		`{"data":[1]}`,
+		`{"shape":[1],"data":[1]}`,
+		`{"shape":[-1,0],"data":[]}`,
+		`{"shape":{"Row":-5,"Col":2},"data":[]}`,
+		`{"shape":[4294967296,4294967296],"data":[]}`,
+		`{"shape":[3037000500,3037000500],"data":[]}`,
+	}
+	for _, s := range bad {
+		var C Matrix
+		if err := json.Unmarshal([]byte(s), &C); err == nil {
+			t.Errorf("error method: UnmarshalJSON accepts %s", s)
+		}
+	}
+}
+
+func TestShapeJSON(t *testing.T) {
+	var s Shape
+	if err := json.Unmarshal([]byte(`{"Row":2,"Col":3}`), &s); err != nil || s != (Shape{2, 3}) {
+		t.Errorf("error method: Shape.UnmarshalJSON, got %v, %v", s, err)
+	}
+
+	for _, b := range []string{`[-5,2]`, `[2,-1]`, `{"Row":-5,"Col":2}`, `[1,2,3]`} {
+		s := Shape{1, 1}
+		if err := json.Unmarshal([]byte(b), &s); err == nil || s != (Shape{1, 1}) {
+			t.Errorf("error method: Shape.UnmarshalJSON accepts %s, got %v", b, s)
+		}
+	}
+}
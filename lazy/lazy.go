@@ -0,0 +1,238 @@
+// Package lazy 提供惰性求值的矩阵表达式。
+//
+// 运算只构建表达式树，Eval 时将连续的逐元素运算（Add、Sub、Mul、ScaleMul、T）融合为一次遍历，
+// 不再为每一步分配临时矩阵；被多处引用的子表达式只计算一次并缓存，融合在此处断开；
+// 连乘 Dot 按动态规划求得的最少乘法次数重新结合，被多处引用的乘积作为整体参与结合。
+// 形状在构建表达式时检查，不匹配时与 matrix 包一样 panic。
+//
+//	// 等价于 A.Add(B).ScaleMul(2).Sub(C)，只分配一个结果矩阵
+//	S := lazy.New(A).Add(lazy.New(B)).ScaleMul(2).Sub(lazy.New(C)).Eval()
+package lazy
+
+import (
+	"fmt"
+
+	"github.com/mrfyo/matrix"
+)
+
+type op int
+
+const (
+	opLeaf op = iota
+	opAdd
+	opSub
+	opMul
+	opScale
+	opT
+	opDot
+)
+
+// Expr 惰性矩阵表达式，同一个 Expr 可以在多处引用，Eval 时只计算一次
+type Expr struct {
+	op    op
+	shape matrix.Shape
+	value matrix.Matrix
+	k     float64
+	args  []*Expr
+}
+
+// New 包装一个矩阵。Eval 前不应修改 A
+func New(A matrix.Matrix) *Expr {
+	return &Expr{op: opLeaf, shape: A.Shape, value: A}
+}
+
+// Shape 返回结果形状
+func (e *Expr) Shape() matrix.Shape {
+	return e.shape
+}
+
+// Add 矩阵相加
+func (e *Expr) Add(f *Expr) *Expr {
+	if matrix.ShapeNotEqual(e.shape, f.shape) {
+		panic(fmt.Sprintf("two matrix cannot [add]. %v x %v", e.shape, f.shape))
+	}
+	return &Expr{op: opAdd, shape: e.shape, args: []*Expr{e, f}}
+}
+
+// Sub 矩阵相减
+func (e *Expr) Sub(f *Expr) *Expr {
+	if matrix.ShapeNotEqual(e.shape, f.shape) {
+		panic(fmt.Sprintf("two matrix cannot [sub]. %v x %v", e.shape, f.shape))
+	}
+	return &Expr{op: opSub, shape: e.shape, args: []*Expr{e, f}}
+}
+
+// Mul 点乘(同位置相乘，形状不变)
+func (e *Expr) Mul(f *Expr) *Expr {
+	if matrix.ShapeNotEqual(e.shape, f.shape) {
+		panic(fmt.Sprintf("two matrix cannot [mul]. %v x %v", e.shape, f.shape))
+	}
+	return &Expr{op: opMul, shape: e.shape, args: []*Expr{e, f}}
+}
+
+// ScaleMul 矩阵比例乘
+func (e *Expr) ScaleMul(k float64) *Expr {
+	return &Expr{op: opScale, shape: e.shape, k: k, args: []*Expr{e}}
+}
+
+// T 转置
+func (e *Expr) T() *Expr {
+	return &Expr{op: opT, shape: matrix.Shape{Row: e.shape.Col, Col: e.shape.Row}, args: []*Expr{e}}
+}
+
+// Dot 矩阵乘法
+func (e *Expr) Dot(f *Expr) *Expr {
+	if e.shape.Col != f.shape.Row {
+		panic(fmt.Sprintf("two matrix cannot [dot]. %v x %v", e.shape, f.shape))
+	}
+	return &Expr{op: opDot, shape: matrix.Shape{Row: e.shape.Row, Col: f.shape.Col}, args: []*Expr{e, f}}
+}
+
+// Eval 计算表达式，结果不与任何输入共享存储
+func (e *Expr) Eval() matrix.Matrix {
+	if e.op == opLeaf {
+		return e.value.Copy()
+	}
+	ev := &evaluator{cache: map[*Expr]matrix.Matrix{}, refs: map[*Expr]int{}}
+	ev.count(e)
+	return ev.materialize(e)
+}
+
+type evaluator struct {
+	// cache 已计算的节点
+	cache map[*Expr]matrix.Matrix
+	// refs 各节点在表达式中被引用的次数
+	refs map[*Expr]int
+	// dots 已执行的矩阵乘法次数
+	dots int
+}
+
+// count 统计各节点被引用的次数，每个节点的子节点只统计一次
+func (ev *evaluator) count(e *Expr) {
+	ev.refs[e]++
+	if ev.refs[e] > 1 {
+		return
+	}
+	for _, a := range e.args {
+		ev.count(a)
+	}
+}
+
+// kernel 按位置计算元素的函数
+type kernel func(i, j int) float64
+
+// materialize 计算节点的值
+func (ev *evaluator) materialize(e *Expr) matrix.Matrix {
+	if e.op == opLeaf {
+		return e.value
+	}
+	if S, ok := ev.cache[e]; ok {
+		return S
+	}
+
+	var S matrix.Matrix
+	if e.op == opDot {
+		var factors []*Expr
+		ev.flattenDot(e.args[0], &factors)
+		ev.flattenDot(e.args[1], &factors)
+		ms := make([]matrix.Matrix, len(factors))
+		for i, f := range factors {
+			ms[i] = ev.materialize(f)
+		}
+		_, split := chainOrder(ms)
+		S = ev.multiplyChain(ms, split, 0, len(ms)-1)
+	} else {
+		// 融合整棵逐元素子树，一次遍历写入结果
+		at := ev.fuse(e)
+		S = matrix.Zeros(e.shape)
+		for i := 0; i < S.Row; i++ {
+			for j := 0; j < S.Col; j++ {
+				S.Set(i, j, at(i, j))
+			}
+		}
+	}
+	ev.cache[e] = S
+	return S
+}
+
+// kernel 返回节点的逐元素计算函数，被多处引用的节点先物化，避免在每个引用处重复计算
+func (ev *evaluator) kernel(e *Expr) kernel {
+	if ev.refs[e] > 1 {
+		A := ev.materialize(e)
+		return A.Get
+	}
+	return ev.fuse(e)
+}
+
+// fuse 将 e 与其逐元素子树组合为一个 kernel
+func (ev *evaluator) fuse(e *Expr) kernel {
+	switch e.op {
+	case opAdd:
+		a, b := ev.kernel(e.args[0]), ev.kernel(e.args[1])
+		return func(i, j int) float64 { return a(i, j) + b(i, j) }
+	case opSub:
+		a, b := ev.kernel(e.args[0]), ev.kernel(e.args[1])
+		return func(i, j int) float64 { return a(i, j) - b(i, j) }
+	case opMul:
+		a, b := ev.kernel(e.args[0]), ev.kernel(e.args[1])
+		return func(i, j int) float64 { return a(i, j) * b(i, j) }
+	case opScale:
+		a, k := ev.kernel(e.args[0]), e.k
+		return func(i, j int) float64 { return a(i, j) * k }
+	case opT:
+		a := ev.kernel(e.args[0])
+		return func(i, j int) float64 { return a(j, i) }
+	}
+	A := ev.materialize(e)
+	return A.Get
+}
+
+// flattenDot 展开连乘的因子，被多处引用的乘积不再展开，作为一个因子只计算一次
+func (ev *evaluator) flattenDot(e *Expr, factors *[]*Expr) {
+	if e.op != opDot || ev.refs[e] > 1 {
+		*factors = append(*factors, e)
+		return
+	}
+	ev.flattenDot(e.args[0], factors)
+	ev.flattenDot(e.args[1], factors)
+}
+
+// chainOrder 矩阵链乘的动态规划，返回最少乘法次数与最优划分，split[i][j] 为 ms[i..j] 的划分点
+func chainOrder(ms []matrix.Matrix) (cost int, split [][]int) {
+	n := len(ms)
+	dims := make([]int, n+1)
+	for i, M := range ms {
+		dims[i] = M.Row
+	}
+	dims[n] = ms[n-1].Col
+
+	m := make([][]int, n)
+	split = make([][]int, n)
+	for i := range m {
+		m[i] = make([]int, n)
+		split[i] = make([]int, n)
+	}
+	for l := 1; l < n; l++ {
+		for i := 0; i+l < n; i++ {
+			j := i + l
+			m[i][j] = -1
+			for k := i; k < j; k++ {
+				c := m[i][k] + m[k+1][j] + dims[i]*dims[k+1]*dims[j+1]
+				if m[i][j] < 0 || c < m[i][j] {
+					m[i][j], split[i][j] = c, k
+				}
+			}
+		}
+	}
+	return m[0][n-1], split
+}
+
+func (ev *evaluator) multiplyChain(ms []matrix.Matrix, split [][]int, i, j int) matrix.Matrix {
+	if i == j {
+		return ms[i]
+	}
+	k := split[i][j]
+	A, B := ev.multiplyChain(ms, split, i, k), ev.multiplyChain(ms, split, k+1, j)
+	ev.dots++
+	return A.Dot(B)
+}
@@ -0,0 +1,152 @@
+package lazy
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/mrfyo/matrix"
+)
+
+func TestEvalMatchesEager(t *testing.T) {
+	src := rand.NewSource(1)
+	A := matrix.Rand(matrix.Shape{Row: 4, Col: 3}, src)
+	B := matrix.Rand(matrix.Shape{Row: 4, Col: 3}, src)
+	C := matrix.Rand(matrix.Shape{Row: 4, Col: 3}, src)
+	D := matrix.Rand(matrix.Shape{Row: 3, Col: 4}, src)
+	a, b, c, d := New(A), New(B), New(C), New(D)
+
+	cases := []struct {
+		name  string
+		lazy  *Expr
+		eager matrix.Matrix
+	}{
+		{"add-scale-sub", a.Add(b).ScaleMul(2).Sub(c), A.Add(B).ScaleMul(2).Sub(C)},
+		{"mul-transpose", a.Mul(d.T()).T(), A.Mul(D.T()).T()},
+		{"dot", a.Dot(d).Add(c.Dot(d)), A.Dot(D).Add(C.Dot(D))},
+		{"chain", a.T().Dot(b).Dot(d).Dot(c), A.T().Dot(B).Dot(D).Dot(C)},
+		{"leaf", a, A},
+	}
+	for _, tc := range cases {
+		got := tc.lazy.Eval()
+		if got.Shape != tc.eager.Shape || !matrix.MatrixEqual(got, tc.eager) {
+			t.Errorf("%s: lazy %v, eager %v", tc.name, got, tc.eager)
+		}
+	}
+
+	// 不含矩阵乘法时结果逐位相同
+	got := a.Add(b).ScaleMul(2).Sub(c).Mul(a).Eval()
+	want := A.Add(B).ScaleMul(2).Sub(C).Mul(A)
+	for i := 0; i < want.Size(); i++ {
+		if got.GetIndex(i) != want.GetIndex(i) {
+			t.Fatalf("fused result differs at %d: %v != %v", i, got.GetIndex(i), want.GetIndex(i))
+		}
+	}
+
+	// 结果不能与输入共享存储
+	got = a.Eval()
+	got.SetIndex(0, 100)
+	if A.GetIndex(0) == 100 {
+		t.Error("Eval of a leaf aliases its input")
+	}
+}
+
+func TestSharedSubexpression(t *testing.T) {
+	A := matrix.Rand(matrix.Shape{Row: 3, Col: 3}, rand.NewSource(2))
+	a := New(A)
+	p := a.Dot(a)
+	got := p.Add(p).Eval()
+	want := A.Dot(A).ScaleMul(2)
+	if !matrix.MatrixEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// 被多处引用的逐元素节点只物化一次
+	s := a.Add(a).ScaleMul(3)
+	root := s.Mul(s.T()).Sub(s)
+	ev := &evaluator{cache: map[*Expr]matrix.Matrix{}, refs: map[*Expr]int{}}
+	ev.count(root)
+	got = ev.materialize(root)
+	want = A.Add(A).ScaleMul(3)
+	want = want.Mul(want.T()).Sub(want)
+	if !matrix.MatrixEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if ev.refs[s] != 3 {
+		t.Errorf("shared node referenced %d times, want 3", ev.refs[s])
+	}
+	if _, ok := ev.cache[s]; !ok {
+		t.Error("shared element-wise node was not materialized")
+	}
+}
+
+func TestSharedDot(t *testing.T) {
+	src := rand.NewSource(3)
+	X := matrix.Rand(matrix.Shape{Row: 2, Col: 3}, src)
+	Y := matrix.Rand(matrix.Shape{Row: 3, Col: 2}, src)
+	Z := matrix.Rand(matrix.Shape{Row: 2, Col: 2}, src)
+	d := New(X).Dot(New(Y))
+	root := d.Dot(d).Add(d.Dot(New(Z)))
+
+	// 被多处引用的乘积只计算一次，不在每个引用处重新展开：X·Y、D·D、D·Z 共 3 次
+	ev := &evaluator{cache: map[*Expr]matrix.Matrix{}, refs: map[*Expr]int{}}
+	ev.count(root)
+	got := ev.materialize(root)
+	D := X.Dot(Y)
+	want := D.Dot(D).Add(D.Dot(Z))
+	if !matrix.MatrixEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if ev.dots != 3 {
+		t.Errorf("matrix products evaluated %d times, want 3", ev.dots)
+	}
+	if _, ok := ev.cache[d]; !ok {
+		t.Error("shared dot node was not materialized")
+	}
+}
+
+func TestChainOrder(t *testing.T) {
+	shapes := func(dims ...int) []matrix.Matrix {
+		ms := make([]matrix.Matrix, len(dims)-1)
+		for i := range ms {
+			ms[i] = matrix.Zeros(matrix.Shape{Row: dims[i], Col: dims[i+1]})
+		}
+		return ms
+	}
+
+	cases := []struct {
+		dims  []int
+		cost  int
+		split int
+	}{
+		// (AB)C: 10*100*5 + 10*5*50
+		{[]int{10, 100, 5, 50}, 7500, 1},
+		// A(BC): 5*100*50 + 10*5*50
+		{[]int{10, 5, 100, 50}, 27500, 0},
+		{[]int{30, 35, 15, 5, 10, 20, 25}, 15125, 2},
+	}
+	for _, c := range cases {
+		cost, split := chainOrder(shapes(c.dims...))
+		if cost != c.cost || split[0][len(c.dims)-2] != c.split {
+			t.Errorf("chainOrder(%v) = %d split %d, want %d split %d", c.dims, cost, split[0][len(c.dims)-2], c.cost, c.split)
+		}
+	}
+}
+
+func TestShapeMismatch(t *testing.T) {
+	a := New(matrix.Zeros(matrix.Shape{Row: 2, Col: 3}))
+	for name, f := range map[string]func(){
+		"add": func() { a.Add(a.T()) },
+		"sub": func() { a.Sub(a.T()) },
+		"mul": func() { a.Mul(a.T()) },
+		"dot": func() { a.Dot(a) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: expected panic", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
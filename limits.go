@@ -0,0 +1,17 @@
+package matrix
+
+// 解码时允许的最大维度与元素个数。头部声明的形状超出时返回错误，
+// maxDecodeSize 个 float64 占 1 GiB。数据分块读取，内存随实际读到的数据增长，
+// 读取不可信的输入时调用方仍应限制输入大小，如使用 io.LimitReader
+const (
+	maxDecodeDim  = 1 << 31
+	maxDecodeSize = 1 << 27
+)
+
+// decodeShapeOK 判断头部声明的形状是否在解码限制之内，乘法不会溢出
+func decodeShapeOK(row, col uint64) bool {
+	if row >= maxDecodeDim || col >= maxDecodeDim {
+		return false
+	}
+	return col == 0 || row <= maxDecodeSize/col
+}
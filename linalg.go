@@ -1,6 +1,17 @@
 package matrix
 
-import "math"
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// ErrSingular Solve 遇到奇异矩阵时以该错误 panic
+var ErrSingular = errors.New("matrix: matrix is singular")
+
+// ErrNoSolution 有限域上的线性方程组无解时以该错误 panic
+var ErrNoSolution = errors.New("matrix: linear system has no solution")
 
 // Det 行列式
 func Det(A Matrix) float64 {
@@ -207,3 +218,141 @@ func QR(A Matrix) (Q Matrix, R Matrix) {
 
 	return
 }
+
+// Solve 列主元高斯消元求解线性方程组 AX = B，B 可以有多列。A 奇异时以 ErrSingular panic
+func Solve(A, B Matrix) (X Matrix) {
+	if A.Col != A.Row {
+		panic("Solve(A, B): matrix A must be square.")
+	}
+	if A.Row != B.Row {
+		panic(fmt.Sprintf("Solve(A, B): shape not match. %v x %v", A.Shape, B.Shape))
+	}
+
+	n := A.Row
+	U := A.Copy()
+	X = B.Copy()
+
+	for j := 0; j < n; j++ {
+		p := j
+		for i := j + 1; i < n; i++ {
+			if math.Abs(U.Get(i, j)) > math.Abs(U.Get(p, j)) {
+				p = i
+			}
+		}
+		if U.Get(p, j) == 0 {
+			panic(ErrSingular)
+		}
+		if p != j {
+			swapRows(U, p, j)
+			swapRows(X, p, j)
+		}
+
+		for i := j + 1; i < n; i++ {
+			c := U.Get(i, j) / U.Get(j, j)
+			if c == 0 {
+				continue
+			}
+			for k := j; k < n; k++ {
+				U.Set(i, k, U.Get(i, k)-c*U.Get(j, k))
+			}
+			for k := 0; k < X.Col; k++ {
+				X.Set(i, k, X.Get(i, k)-c*X.Get(j, k))
+			}
+		}
+	}
+
+	// 回代
+	for i := n - 1; i >= 0; i-- {
+		for k := 0; k < X.Col; k++ {
+			v := X.Get(i, k)
+			for j := i + 1; j < n; j++ {
+				v -= U.Get(i, j) * X.Get(j, k)
+			}
+			X.Set(i, k, v/U.Get(i, i))
+		}
+	}
+	return
+}
+
+// swapRows 原地交换两行
+func swapRows(A Matrix, i, j int) {
+	for k := 0; k < A.Col; k++ {
+		a, b := A.Get(i, k), A.Get(j, k)
+		A.Set(i, k, b)
+		A.Set(j, k, a)
+	}
+}
+
+// EigSym 实对称矩阵特征分解（循环 Jacobi 法）。
+// 特征值按降序排列为行向量 D，V 的第 j 列为对应的单位特征向量
+func EigSym(A Matrix) (D Matrix, V Matrix) {
+	if A.Col != A.Row {
+		panic("EigSym(A): matrix A must be square.")
+	}
+	n := A.Row
+	S := A.Copy()
+	V = Eye(n)
+
+	for sweep := 0; sweep < 100; sweep++ {
+		off := 0.0
+		for i := 0; i < n; i++ {
+			for j := i + 1; j < n; j++ {
+				off += S.Get(i, j) * S.Get(i, j)
+			}
+		}
+		if off < 1e-30 {
+			break
+		}
+
+		for p := 0; p < n; p++ {
+			for q := p + 1; q < n; q++ {
+				apq := S.Get(p, q)
+				if apq == 0 {
+					continue
+				}
+				// 旋转角使 S[p][q] 变为 0
+				theta := (S.Get(q, q) - S.Get(p, p)) / (2 * apq)
+				t := 1 / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+				if theta < 0 {
+					t = -t
+				}
+				c := 1 / math.Sqrt(t*t+1)
+				s := t * c
+
+				for k := 0; k < n; k++ {
+					akp, akq := S.Get(k, p), S.Get(k, q)
+					S.Set(k, p, c*akp-s*akq)
+					S.Set(k, q, s*akp+c*akq)
+				}
+				for k := 0; k < n; k++ {
+					apk, aqk := S.Get(p, k), S.Get(q, k)
+					S.Set(p, k, c*apk-s*aqk)
+					S.Set(q, k, s*apk+c*aqk)
+				}
+				for k := 0; k < n; k++ {
+					vkp, vkq := V.Get(k, p), V.Get(k, q)
+					V.Set(k, p, c*vkp-s*vkq)
+					V.Set(k, q, s*vkp+c*vkq)
+				}
+			}
+		}
+	}
+
+	// 按特征值降序排列
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return S.Get(order[a], order[a]) > S.Get(order[b], order[b])
+	})
+
+	D = Zeros(Shape{1, n})
+	W := Zeros(A.Shape)
+	for j, k := range order {
+		D.SetIndex(j, S.Get(k, k))
+		W.SetCol(j, V.GetCol(k))
+	}
+	V = W
+	return
+}
@@ -0,0 +1,395 @@
+package matrix
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Matrix Market 格式头部取值
+const (
+	MarketCoordinate = "coordinate"
+	MarketArray      = "array"
+
+	MarketReal    = "real"
+	MarketInteger = "integer"
+	MarketPattern = "pattern"
+
+	MarketGeneral       = "general"
+	MarketSymmetric     = "symmetric"
+	MarketSkewSymmetric = "skew-symmetric"
+)
+
+// MarketHeader Matrix Market 文件头 %%MatrixMarket matrix <Format> <Field> <Symmetry>
+type MarketHeader struct {
+	Format   string
+	Field    string
+	Symmetry string
+}
+
+// Triplet 稀疏矩阵的一个元素，行列下标从 0 开始
+type Triplet struct {
+	I, J int
+	V    float64
+}
+
+// Triplets 三元组（COO）表示的稀疏矩阵
+type Triplets struct {
+	Shape
+	Entries []Triplet
+}
+
+// NewTriplets 提取稠密矩阵的非零元
+func NewTriplets(A Matrix) *Triplets {
+	T := &Triplets{Shape: A.Shape}
+	for i := 0; i < A.Row; i++ {
+		for j := 0; j < A.Col; j++ {
+			if v := A.Get(i, j); v != 0 {
+				T.Entries = append(T.Entries, Triplet{I: i, J: j, V: v})
+			}
+		}
+	}
+	return T
+}
+
+// Dense 展开为稠密矩阵，重复的下标累加
+func (T *Triplets) Dense() Matrix {
+	A := Zeros(T.Shape)
+	for _, e := range T.Entries {
+		A.Set(e.I, e.J, A.Get(e.I, e.J)+e.V)
+	}
+	return A
+}
+
+// Market 读取的 Matrix Market 数据。
+// array 格式存放在 Dense 中；coordinate 格式存放在 Triplets 中，对称部分已展开
+type Market struct {
+	Header   MarketHeader
+	Dense    Matrix
+	Triplets *Triplets
+}
+
+// Matrix 以稠密矩阵返回数据
+func (m *Market) Matrix() Matrix {
+	if m.Triplets != nil {
+		return m.Triplets.Dense()
+	}
+	return m.Dense
+}
+
+// ReadMarket 读取 Matrix Market 格式，支持 coordinate/array、real/integer/pattern、
+// general/symmetric/skew-symmetric
+func ReadMarket(r io.Reader) (*Market, error) {
+	br := bufio.NewReader(r)
+	line := 0
+	next := func() (string, error) {
+		for {
+			text, err := br.ReadString('\n')
+			if text == "" && err != nil {
+				if err == io.EOF {
+					return "", io.ErrUnexpectedEOF
+				}
+				return "", err
+			}
+			line++
+			text = strings.TrimSpace(text)
+			if text != "" && !strings.HasPrefix(text, "%") {
+				return text, nil
+			}
+			if err != nil {
+				return "", io.ErrUnexpectedEOF
+			}
+		}
+	}
+	fail := func(format string, args ...interface{}) error {
+		return fmt.Errorf("matrix: market line %d: %s", line, fmt.Sprintf(format, args...))
+	}
+
+	// 文件头
+	text, err := br.ReadString('\n')
+	if err != nil && text == "" {
+		return nil, fmt.Errorf("matrix: market: missing header")
+	}
+	line++
+	h, err := parseMarketHeader(text)
+	if err != nil {
+		return nil, fail("%v", err)
+	}
+
+	text, err = next()
+	if err != nil {
+		return nil, fail("missing size line: %v", err)
+	}
+	size, err := parseInts(strings.Fields(text))
+	if err != nil {
+		return nil, fail("%v", err)
+	}
+
+	m := &Market{Header: h}
+	if h.Format == MarketArray {
+		if len(size) != 2 {
+			return nil, fail("array size line must have 2 integers")
+		}
+		shape := Shape{size[0], size[1]}
+		if shape.Row < 0 || shape.Col < 0 {
+			return nil, fail("invalid size %v", shape)
+		}
+		if !decodeShapeOK(uint64(shape.Row), uint64(shape.Col)) {
+			return nil, fail("size %v too large", shape)
+		}
+		if h.Symmetry != MarketGeneral && shape.Row != shape.Col {
+			return nil, fail("%s matrix must be square", h.Symmetry)
+		}
+		A := Zeros(shape)
+		// 列优先存储，对称矩阵只存下三角
+		for j := 0; j < shape.Col; j++ {
+			start := 0
+			switch h.Symmetry {
+			case MarketSymmetric:
+				start = j
+			case MarketSkewSymmetric:
+				start = j + 1
+			}
+			for i := start; i < shape.Row; i++ {
+				text, err = next()
+				if err != nil {
+					return nil, fail("%v", err)
+				}
+				v, err := strconv.ParseFloat(text, 64)
+				if err != nil {
+					return nil, fail("invalid value %q", text)
+				}
+				A.Set(i, j, v)
+				switch h.Symmetry {
+				case MarketSymmetric:
+					A.Set(j, i, v)
+				case MarketSkewSymmetric:
+					A.Set(j, i, -v)
+				}
+			}
+		}
+		m.Dense = A
+		return m, nil
+	}
+
+	if len(size) != 3 {
+		return nil, fail("coordinate size line must have 3 integers")
+	}
+	T := &Triplets{Shape: Shape{size[0], size[1]}}
+	if T.Row < 0 || T.Col < 0 || size[2] < 0 {
+		return nil, fail("invalid size %v", size)
+	}
+	if !decodeShapeOK(uint64(T.Row), uint64(T.Col)) {
+		return nil, fail("size %v too large", T.Shape)
+	}
+	for k := 0; k < size[2]; k++ {
+		text, err = next()
+		if err != nil {
+			return nil, fail("%v", err)
+		}
+		fields := strings.Fields(text)
+		want := 3
+		if h.Field == MarketPattern {
+			want = 2
+		}
+		if len(fields) != want {
+			return nil, fail("expect %d fields, got %d", want, len(fields))
+		}
+		ij, err := parseInts(fields[:2])
+		if err != nil {
+			return nil, fail("%v", err)
+		}
+		i, j := ij[0]-1, ij[1]-1
+		if i < 0 || i >= T.Row || j < 0 || j >= T.Col {
+			return nil, fail("index (%d, %d) out of bounds %v", ij[0], ij[1], T.Shape)
+		}
+		v := 1.0
+		if h.Field != MarketPattern {
+			v, err = strconv.ParseFloat(fields[2], 64)
+			if err != nil {
+				return nil, fail("invalid value %q", fields[2])
+			}
+		}
+
+		T.Entries = append(T.Entries, Triplet{I: i, J: j, V: v})
+		if i != j {
+			switch h.Symmetry {
+			case MarketSymmetric:
+				T.Entries = append(T.Entries, Triplet{I: j, J: i, V: v})
+			case MarketSkewSymmetric:
+				T.Entries = append(T.Entries, Triplet{I: j, J: i, V: -v})
+			}
+		}
+	}
+	m.Triplets = T
+	return m, nil
+}
+
+func parseMarketHeader(text string) (h MarketHeader, err error) {
+	fields := strings.Fields(strings.ToLower(text))
+	if len(fields) != 5 || fields[0] != "%%matrixmarket" || fields[1] != "matrix" {
+		return h, fmt.Errorf("invalid header %q", strings.TrimSpace(text))
+	}
+	h = MarketHeader{Format: fields[2], Field: fields[3], Symmetry: fields[4]}
+	return h, h.validate()
+}
+
+func (h MarketHeader) validate() error {
+	switch h.Format {
+	case MarketCoordinate, MarketArray:
+	default:
+		return fmt.Errorf("unsupported format %q", h.Format)
+	}
+	switch h.Field {
+	case MarketReal, MarketInteger:
+	case MarketPattern:
+		if h.Format == MarketArray {
+			return fmt.Errorf("pattern field requires coordinate format")
+		}
+	default:
+		return fmt.Errorf("unsupported field %q", h.Field)
+	}
+	switch h.Symmetry {
+	case MarketGeneral, MarketSymmetric, MarketSkewSymmetric:
+	default:
+		return fmt.Errorf("unsupported symmetry %q", h.Symmetry)
+	}
+	return nil
+}
+
+func parseInts(fields []string) ([]int, error) {
+	v := make([]int, len(fields))
+	for i, f := range fields {
+		n, err := strconv.Atoi(f)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer %q", f)
+		}
+		v[i] = n
+	}
+	return v, nil
+}
+
+// WriteMarket 将稠密矩阵按 h 指定的格式写出。
+// coordinate 格式只写非零元；对称格式只写下三角，且要求矩阵满足对应的对称性
+func WriteMarket(w io.Writer, A Matrix, h MarketHeader) error {
+	if err := h.validate(); err != nil {
+		return fmt.Errorf("matrix: market: %v", err)
+	}
+	if err := checkMarketSymmetry(A, h.Symmetry); err != nil {
+		return err
+	}
+	if h.Format == MarketCoordinate {
+		T := NewTriplets(A)
+		lower := T.Entries[:0]
+		for _, e := range T.Entries {
+			if !offTriangle(e, h.Symmetry) {
+				lower = append(lower, e)
+			}
+		}
+		T.Entries = lower
+		return WriteMarketTriplets(w, T, h)
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "%%%%MatrixMarket matrix %s %s %s\n", h.Format, h.Field, h.Symmetry)
+	fmt.Fprintf(bw, "%d %d\n", A.Row, A.Col)
+	for j := 0; j < A.Col; j++ {
+		start := 0
+		switch h.Symmetry {
+		case MarketSymmetric:
+			start = j
+		case MarketSkewSymmetric:
+			start = j + 1
+		}
+		for i := start; i < A.Row; i++ {
+			s, err := marketValue(A.Get(i, j), h.Field)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(bw, s)
+		}
+	}
+	return bw.Flush()
+}
+
+// WriteMarketTriplets 以 coordinate 格式写出三元组。对称格式只能包含下三角中的元素，
+// 斜对称格式只能包含严格下三角中的元素，否则返回错误
+func WriteMarketTriplets(w io.Writer, T *Triplets, h MarketHeader) error {
+	if h.Format != MarketCoordinate {
+		return fmt.Errorf("matrix: market: triplets require coordinate format")
+	}
+	if err := h.validate(); err != nil {
+		return fmt.Errorf("matrix: market: %v", err)
+	}
+	if h.Symmetry != MarketGeneral && T.Row != T.Col {
+		return fmt.Errorf("matrix: market: %s matrix must be square", h.Symmetry)
+	}
+
+	entries := T.Entries
+	for _, e := range entries {
+		if offTriangle(e, h.Symmetry) {
+			return fmt.Errorf("matrix: market: entry (%d, %d) is outside the stored triangle of a %s matrix", e.I+1, e.J+1, h.Symmetry)
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "%%%%MatrixMarket matrix %s %s %s\n", h.Format, h.Field, h.Symmetry)
+	fmt.Fprintf(bw, "%d %d %d\n", T.Row, T.Col, len(entries))
+	for _, e := range entries {
+		if h.Field == MarketPattern {
+			fmt.Fprintf(bw, "%d %d\n", e.I+1, e.J+1)
+			continue
+		}
+		s, err := marketValue(e.V, h.Field)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(bw, "%d %d %s\n", e.I+1, e.J+1, s)
+	}
+	return bw.Flush()
+}
+
+// offTriangle 判断元素是否位于对称格式不存储的部分
+func offTriangle(e Triplet, symmetry string) bool {
+	switch symmetry {
+	case MarketSymmetric:
+		return e.I < e.J
+	case MarketSkewSymmetric:
+		return e.I <= e.J
+	}
+	return false
+}
+
+func marketValue(v float64, field string) (string, error) {
+	if field == MarketInteger {
+		if v != math.Trunc(v) || math.IsInf(v, 0) {
+			return "", fmt.Errorf("matrix: market: %v is not an integer", v)
+		}
+		return strconv.FormatFloat(v, 'f', 0, 64), nil
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64), nil
+}
+
+func checkMarketSymmetry(A Matrix, symmetry string) error {
+	if symmetry == MarketGeneral {
+		return nil
+	}
+	if A.Row != A.Col {
+		return fmt.Errorf("matrix: market: %s matrix must be square", symmetry)
+	}
+	sign := 1.0
+	if symmetry == MarketSkewSymmetric {
+		sign = -1
+	}
+	for i := 0; i < A.Row; i++ {
+		for j := 0; j <= i; j++ {
+			if A.Get(i, j) != sign*A.Get(j, i) {
+				return fmt.Errorf("matrix: market: matrix is not %s", symmetry)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package matrix
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestReadMarketCoordinate(t *testing.T) {
+	input := `%%MatrixMarket matrix coordinate real symmetric
+% a comment
+3 3 4
+1 1 2.0
+2 1 -1
+3 2 4.5
+3 3 1e1
+`
+	m, err := ReadMarket(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	Expected := Builder().Row().Link(2, -1, 0).Link(-1, 0, 4.5).Link(0, 4.5, 10).Build()
+	if m.Triplets == nil || len(m.Triplets.Entries) != 6 || !MatrixEqual(m.Matrix(), Expected) {
+		t.Errorf("error method: ReadMarket got %v", m.Matrix())
+	}
+
+	input = "%%MatrixMarket matrix coordinate pattern skew-symmetric\n2 2 1\n2 1\n"
+	m, err = ReadMarket(strings.NewReader(input))
+	if err != nil || !MatrixEqual(m.Matrix(), Builder().Row().Link(0, -1).Link(1, 0).Build()) {
+		t.Error("error method: ReadMarket pattern")
+	}
+}
+
+func TestReadMarketArray(t *testing.T) {
+	input := "%%MatrixMarket matrix array integer general\n2 3\n1\n2\n3\n4\n5\n6\n"
+	m, err := ReadMarket(strings.NewReader(input))
+	if err != nil || !MatrixEqual(m.Dense, Builder().Row().Link(1, 3, 5).Link(2, 4, 6).Build()) {
+		t.Error("error method: ReadMarket array")
+	}
+
+	bad := []string{
+		"%%MatrixMarket matrix array pattern general\n1 1\n1\n",
+		"%%MatrixMarket matrix coordinate real general\n2 2 1\n3 1 1\n",
+		"%%MatrixMarket matrix coordinate real general\n2 2 2\n1 1 1\n",
+		"%%MatrixMarket matrix coordinate complex general\n1 1 1\n1 1 1 0\n",
+		// 超出解码限制或乘积溢出的形状
+		"%%MatrixMarket matrix array real general\n4294967296 4294967296\n1\n",
+		"%%MatrixMarket matrix array real general\n100000 100000000\n1\n",
+		"%%MatrixMarket matrix array real general\n3037000500 3037000500\n1\n",
+		"%%MatrixMarket matrix coordinate real general\n4294967296 4294967296 1\n1 1 1\n",
+		"%%MatrixMarket matrix coordinate real general\n100000 100000000 1\n1 1 1\n",
+	}
+	for _, s := range bad {
+		if _, err := ReadMarket(strings.NewReader(s)); err == nil {
+			t.Errorf("error method: ReadMarket accepts %q", s)
+		}
+	}
+}
+
+func TestWriteMarket(t *testing.T) {
+	A := Builder().Row().Link(1, 2, 0).Link(2, 0, -3).Link(0, -3, 5).Build()
+
+	headers := []MarketHeader{
+		{MarketArray, MarketReal, MarketGeneral},
+		{MarketArray, MarketInteger, MarketSymmetric},
+		{MarketCoordinate, MarketReal, MarketGeneral},
+		{MarketCoordinate, MarketInteger, MarketSymmetric},
+	}
+	for _, h := range headers {
+		var buf bytes.Buffer
+		if err := WriteMarket(&buf, A, h); err != nil {
+			t.Fatal(err)
+		}
+		m, err := ReadMarket(&buf)
+		if err != nil || m.Header != h || !MatrixEqual(m.Matrix(), A) {
+			t.Errorf("error method: WriteMarket round trip %v", h)
+		}
+	}
+
+	var buf bytes.Buffer
+	NS := Builder().Row().Link(1, 2).Link(3, 4).Build()
+	for _, h := range []MarketHeader{
+		{MarketArray, MarketReal, MarketSymmetric},
+		{MarketCoordinate, MarketReal, MarketSymmetric},
+		{MarketCoordinate, MarketReal, MarketSkewSymmetric},
+		{MarketCoordinate, "double", MarketGeneral},
+	} {
+		if err := WriteMarket(&buf, NS, h); err == nil {
+			t.Errorf("error method: WriteMarket accepts %v", h)
+		}
+	}
+
+	T := &Triplets{Shape: Shape{2, 2}, Entries: []Triplet{{0, 0, 1}, {0, 1, 2}, {1, 0, 2}}}
+	if err := WriteMarketTriplets(&buf, T, MarketHeader{MarketCoordinate, MarketReal, MarketSymmetric}); err == nil {
+		t.Error("error method: WriteMarketTriplets accepts upper-triangle entry")
+	}
+	T.Entries = T.Entries[2:]
+	buf.Reset()
+	if err := WriteMarketTriplets(&buf, T, MarketHeader{MarketCoordinate, MarketReal, MarketSymmetric}); err != nil {
+		t.Error("error method: WriteMarketTriplets", err)
+	}
+}
@@ -0,0 +1,225 @@
+// Package markov 提供有限状态离散时间马尔可夫链的分析。
+//
+// 转移矩阵 P 为 n x n 的行随机矩阵，P[i][j] 为由状态 i 转移到 j 的概率。
+// 各函数在 P 不是行随机矩阵时 panic，可先用 Validate 检查。
+//
+//	pi := markov.Stationary(P, 1e-12)   // 1 x n 平稳分布
+//	N := markov.Fundamental(P)          // 吸收链的基本矩阵
+package markov
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/mrfyo/matrix"
+)
+
+// tolerance 行和与 1 的允许误差
+const tolerance = 1e-9
+
+// Validate 检查 P 是否为行随机矩阵：方阵，元素非负，每行之和与 1 的误差不超过 tol
+func Validate(P matrix.Matrix, tol float64) error {
+	if P.Row != P.Col {
+		return fmt.Errorf("markov: transition matrix must be square, got %v", P.Shape)
+	}
+	for i := 0; i < P.Row; i++ {
+		sum := 0.0
+		for j := 0; j < P.Col; j++ {
+			p := P.Get(i, j)
+			if p < 0 || math.IsNaN(p) {
+				return fmt.Errorf("markov: P[%d][%d] = %v is not a probability", i, j, p)
+			}
+			sum += p
+		}
+		if math.Abs(sum-1) > tol {
+			return fmt.Errorf("markov: row %d sums to %v", i, sum)
+		}
+	}
+	return nil
+}
+
+func mustValidate(P matrix.Matrix) {
+	if err := Validate(P, tolerance); err != nil {
+		panic(err)
+	}
+}
+
+// Stationary 幂迭代求平稳分布 π = πP，返回 1 x n 行向量。
+// 迭代使用惰性链 (I + P) / 2，它与 P 有相同的平稳分布且非周期，因此周期链同样收敛。
+// 1-范数变化小于 tol 或迭代 100000 次后停止；链可约时结果依赖于均匀初始分布
+func Stationary(P matrix.Matrix, tol float64) matrix.Matrix {
+	mustValidate(P)
+	n := P.Row
+	pi := matrix.Full(matrix.Shape{Row: 1, Col: n}, 1/float64(n))
+	L := P.Add(matrix.Eye(n)).ScaleMul(0.5)
+	for it := 0; it < 100000; it++ {
+		next := pi.Dot(L)
+		diff := 0.0
+		for j := 0; j < n; j++ {
+			diff += math.Abs(next.Get(0, j) - pi.Get(0, j))
+		}
+		pi = next
+		if diff < tol {
+			break
+		}
+	}
+	return pi
+}
+
+// NStep n 步转移矩阵 Pⁿ，用反复平方计算
+func NStep(P matrix.Matrix, n int) matrix.Matrix {
+	mustValidate(P)
+	if n < 0 {
+		panic(fmt.Sprintf("NStep(P, %d): steps must be non-negative.", n))
+	}
+	S, B := matrix.Eye(P.Row), P
+	for ; n > 0; n >>= 1 {
+		if n&1 == 1 {
+			S = S.Dot(B)
+		}
+		B = B.Dot(B)
+	}
+	return S
+}
+
+// Absorbing 划分吸收态（P[i][i] = 1）与非吸收态，均按编号升序
+func Absorbing(P matrix.Matrix) (transient, absorbing []int) {
+	mustValidate(P)
+	for i := 0; i < P.Row; i++ {
+		if P.Get(i, i) == 1 {
+			absorbing = append(absorbing, i)
+		} else {
+			transient = append(transient, i)
+		}
+	}
+	return
+}
+
+// sub 取 rows x cols 子矩阵
+func sub(P matrix.Matrix, rows, cols []int) matrix.Matrix {
+	S := matrix.Zeros(matrix.Shape{Row: len(rows), Col: len(cols)})
+	for a, i := range rows {
+		for b, j := range cols {
+			S.Set(a, b, P.Get(i, j))
+		}
+	}
+	return S
+}
+
+// Fundamental 吸收链的基本矩阵 N = (I - Q)⁻¹，Q 为非吸收态之间的转移矩阵，
+// N[i][j] 为从第 i 个非吸收态出发、被吸收前访问第 j 个非吸收态的期望次数，行列顺序同 Absorbing。
+// 存在无法到达吸收态的非吸收态时以包装 matrix.ErrSingular 的错误 panic
+func Fundamental(P matrix.Matrix) matrix.Matrix {
+	transient, absorbing := Absorbing(P)
+	mustReach(P, absorbing, "absorbing state")
+	Q := sub(P, transient, transient)
+	return matrix.Solve(matrix.Eye(Q.Row).Sub(Q), matrix.Eye(Q.Row))
+}
+
+// AbsorptionProbabilities 吸收概率 B = N·R，B[i][k] 为从第 i 个非吸收态出发最终被第 k 个吸收态吸收的概率
+func AbsorptionProbabilities(P matrix.Matrix) matrix.Matrix {
+	transient, absorbing := Absorbing(P)
+	return Fundamental(P).Dot(sub(P, transient, absorbing))
+}
+
+// ExpectedSteps 从各非吸收态出发到被吸收的期望步数 t = N·1，返回列向量
+func ExpectedSteps(P matrix.Matrix) matrix.Matrix {
+	N := Fundamental(P)
+	return N.Dot(matrix.Ones(matrix.Shape{Row: N.Col, Col: 1}))
+}
+
+// HittingTimes 从各状态出发首次到达 target 中任一状态的期望步数，返回 n x 1 列向量，
+// target 中的状态为 0。存在以正概率永不到达 target 的状态时以包装 matrix.ErrSingular 的错误 panic
+func HittingTimes(P matrix.Matrix, target []int) matrix.Matrix {
+	mustValidate(P)
+	n := P.Row
+	hit := make([]bool, n)
+	for _, s := range target {
+		if s < 0 || s >= n {
+			panic(fmt.Sprintf("HittingTimes(P, target): state %d out of range [0, %d).", s, n))
+		}
+		hit[s] = true
+	}
+	var rest []int
+	for i := 0; i < n; i++ {
+		if !hit[i] {
+			rest = append(rest, i)
+		}
+	}
+
+	// (I - Q) h = 1，Q 为 target 以外状态之间的转移矩阵
+	h := matrix.Zeros(matrix.Shape{Row: n, Col: 1})
+	if len(rest) == 0 {
+		return h
+	}
+	mustReach(P, target, "target state")
+	Q := sub(P, rest, rest)
+	x := matrix.Solve(matrix.Eye(Q.Row).Sub(Q), matrix.Ones(matrix.Shape{Row: Q.Row, Col: 1}))
+	for a, i := range rest {
+		h.Set(i, 0, x.Get(a, 0))
+	}
+	return h
+}
+
+// mustReach 沿 P 的非零元反向广度优先搜索，任一状态无法到达 target 时 panic。
+// 有限链中能到达 target 的状态以概率 1 到达，因此这正是 I - Q 可逆的条件，
+// 不依赖于消元时主元是否恰好为 0
+func mustReach(P matrix.Matrix, target []int, what string) {
+	n := P.Row
+	seen := make([]bool, n)
+	queue := make([]int, 0, n)
+	for _, s := range target {
+		if !seen[s] {
+			seen[s] = true
+			queue = append(queue, s)
+		}
+	}
+	for head := 0; head < len(queue); head++ {
+		v := queue[head]
+		for u := 0; u < n; u++ {
+			if !seen[u] && P.Get(u, v) != 0 {
+				seen[u] = true
+				queue = append(queue, u)
+			}
+		}
+	}
+	for i, ok := range seen {
+		if !ok {
+			panic(fmt.Errorf("markov: state %d cannot reach any %s: %w", i, what, matrix.ErrSingular))
+		}
+	}
+}
+
+// Simulate 从 start 出发模拟 steps 步，返回长度为 steps + 1 的状态序列，相同的 src 得到相同的轨迹
+func Simulate(P matrix.Matrix, start, steps int, src rand.Source) []int {
+	mustValidate(P)
+	n := P.Row
+	if start < 0 || start >= n {
+		panic(fmt.Sprintf("Simulate(P, %d, steps, src): state out of range [0, %d).", start, n))
+	}
+	if steps < 0 {
+		panic(fmt.Sprintf("Simulate(P, start, %d, src): steps must be non-negative.", steps))
+	}
+	r := rand.New(src)
+	path := make([]int, 1, steps+1)
+	path[0] = start
+	s := start
+	for k := 0; k < steps; k++ {
+		u := r.Float64()
+		next := n - 1
+		for j := 0; j < n; j++ {
+			if u -= P.Get(s, j); u < 0 {
+				next = j
+				break
+			}
+		}
+		// 浮点误差使 u 未减到 0 以下时，取最后一个概率非零的状态
+		for next > 0 && P.Get(s, next) == 0 {
+			next--
+		}
+		s = next
+		path = append(path, s)
+	}
+	return path
+}
@@ -0,0 +1,171 @@
+package markov
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/mrfyo/matrix"
+)
+
+// ruin 公平赌徒破产链，状态 0 与 4 为吸收态
+func ruin() matrix.Matrix {
+	return matrix.Builder().Row().
+		Link(1, 0, 0, 0, 0).
+		Link(0.5, 0, 0.5, 0, 0).
+		Link(0, 0.5, 0, 0.5, 0).
+		Link(0, 0, 0.5, 0, 0.5).
+		Link(0, 0, 0, 0, 1).
+		Build()
+}
+
+func twoState() matrix.Matrix {
+	return matrix.Builder().Row().Link(0.9, 0.1).Link(0.5, 0.5).Build()
+}
+
+func TestValidate(t *testing.T) {
+	if err := Validate(ruin(), 1e-12); err != nil {
+		t.Errorf("error method: Validate, got %v, want nil", err)
+	}
+
+	bad := []matrix.Matrix{
+		matrix.Builder().Row().Link(0.5, 0.5).Build(),
+		matrix.Builder().Row().Link(1.2, -0.2).Link(0.5, 0.5).Build(),
+		matrix.Builder().Row().Link(0.5, 0.4).Link(0.5, 0.5).Build(),
+	}
+	for _, P := range bad {
+		if Validate(P, 1e-12) == nil {
+			t.Errorf("error method: Validate(%v), got nil, want error", P)
+		}
+	}
+}
+
+func TestStationary(t *testing.T) {
+	PiExpected := matrix.Builder().Row().Link(5.0/6, 1.0/6).Build()
+
+	if got := Stationary(twoState(), 1e-14); !matrix.MatrixEqual(PiExpected, got) {
+		t.Errorf("error method: Stationary, got %v, want %v", got, PiExpected)
+	}
+
+	// 周期链也应收敛
+	flip := matrix.Builder().Row().Link(0, 1).Link(1, 0).Build()
+	PiExpected = matrix.Builder().Row().Link(0.5, 0.5).Build()
+
+	if got := Stationary(flip, 1e-14); !matrix.MatrixEqual(PiExpected, got) {
+		t.Errorf("error method: Stationary periodic, got %v, want %v", got, PiExpected)
+	}
+}
+
+func TestNStep(t *testing.T) {
+	P := ruin()
+
+	if got := NStep(P, 0); !matrix.MatrixEqual(matrix.Eye(5), got) {
+		t.Errorf("error method: NStep(P, 0), got %v, want I", got)
+	}
+
+	S := P
+	for n := 1; n <= 5; n++ {
+		if got := NStep(P, n); !matrix.MatrixEqual(S, got) {
+			t.Errorf("error method: NStep(P, %d), got %v, want %v", n, got, S)
+		}
+		S = S.Dot(P)
+	}
+}
+
+func TestAbsorbing(t *testing.T) {
+	P := ruin()
+	transient, absorbing := Absorbing(P)
+
+	if !reflect.DeepEqual(transient, []int{1, 2, 3}) || !reflect.DeepEqual(absorbing, []int{0, 4}) {
+		t.Errorf("error method: Absorbing, got %v, %v, want [1 2 3], [0 4]", transient, absorbing)
+	}
+
+	NExpected := matrix.Builder().Row().Link(1.5, 1, 0.5).Link(1, 2, 1).Link(0.5, 1, 1.5).Build()
+	if got := Fundamental(P); !matrix.MatrixEqual(NExpected, got) {
+		t.Errorf("error method: Fundamental, got %v, want %v", got, NExpected)
+	}
+
+	BExpected := matrix.Builder().Row().Link(0.75, 0.25).Link(0.5, 0.5).Link(0.25, 0.75).Build()
+	if got := AbsorptionProbabilities(P); !matrix.MatrixEqual(BExpected, got) {
+		t.Errorf("error method: AbsorptionProbabilities, got %v, want %v", got, BExpected)
+	}
+
+	TExpected := matrix.Builder().Col().Link(3, 4, 3).Build()
+	if got := ExpectedSteps(P); !matrix.MatrixEqual(TExpected, got) {
+		t.Errorf("error method: ExpectedSteps, got %v, want %v", got, TExpected)
+	}
+
+	// 状态 1、2 互相转移，永不被吸收；状态 0、1 构成闭类，消元时主元不恰好为 0
+	for _, stuck := range []matrix.Matrix{
+		matrix.Builder().Row().Link(1, 0, 0).Link(0, 0, 1).Link(0, 1, 0).Build(),
+		matrix.Builder().Row().Link(0.3, 0.7, 0).Link(0.1, 0.9, 0).Link(0, 0, 1).Build(),
+	} {
+		if err := recoverError(func() { Fundamental(stuck) }); !errors.Is(err, matrix.ErrSingular) {
+			t.Errorf("error method: Fundamental unabsorbed states, got %v, want ErrSingular", err)
+		}
+		if err := recoverError(func() { HittingTimes(stuck, []int{2}) }); !errors.Is(err, matrix.ErrSingular) {
+			t.Errorf("error method: HittingTimes unreachable target, got %v, want ErrSingular", err)
+		}
+	}
+}
+
+// recoverError 调用 f 并返回其以 error panic 的值
+func recoverError(f func()) (err error) {
+	defer func() {
+		err, _ = recover().(error)
+	}()
+	f()
+	return nil
+}
+
+func TestHittingTimes(t *testing.T) {
+	// 每步以 0.1 的概率离开状态 0，期望 10 步
+	HExpected := matrix.Builder().Col().Link(10, 0).Build()
+	if got := HittingTimes(twoState(), []int{1}); !matrix.MatrixEqual(HExpected, got) {
+		t.Errorf("error method: HittingTimes, got %v, want %v", got, HExpected)
+	}
+
+	HExpected = matrix.Builder().Col().Link(0, 3, 4, 3, 0).Build()
+	if got := HittingTimes(ruin(), []int{0, 4}); !matrix.MatrixEqual(HExpected, got) {
+		t.Errorf("error method: HittingTimes, got %v, want %v", got, HExpected)
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	P := twoState()
+	a := Simulate(P, 0, 100, rand.NewSource(7))
+	b := Simulate(P, 0, 100, rand.NewSource(7))
+
+	if !reflect.DeepEqual(a, b) || len(a) != 101 || a[0] != 0 {
+		t.Errorf("error method: Simulate is not reproducible or has wrong length, got %v", a)
+	}
+
+	// 长轨迹的状态频率接近平稳分布
+	path := Simulate(P, 0, 200000, rand.NewSource(1))
+	count := 0
+	for _, s := range path {
+		count += s
+	}
+	if f := float64(count) / float64(len(path)); math.Abs(f-1.0/6) > 0.01 {
+		t.Errorf("error method: Simulate frequency of state 1, got %v, want about %v", f, 1.0/6)
+	}
+
+	// 只沿概率非零的边转移
+	R := ruin()
+	path = Simulate(R, 2, 50, rand.NewSource(3))
+	for k := 1; k < len(path); k++ {
+		if R.Get(path[k-1], path[k]) == 0 {
+			t.Errorf("error method: Simulate, impossible transition %d -> %d", path[k-1], path[k])
+			break
+		}
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("error method: Simulate negative steps, got no panic, want panic")
+		}
+	}()
+	Simulate(P, 0, -1, rand.NewSource(1))
+}
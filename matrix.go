@@ -3,7 +3,6 @@ package matrix
 import (
 	"fmt"
 	"math"
-	"strings"
 )
 
 type Shape struct {
@@ -62,24 +61,6 @@ func (A Matrix) SetIndex(ind int, v float64) {
 	A.array[ind] = v
 }
 
-func (A Matrix) String() string {
-	var Cols []string
-	for i := 0; i < A.Row; i++ {
-		var Col []string
-		for j := 0; j < A.Col; j++ {
-			v := A.Get(i, j)
-			if v-math.Floor(v) < 1e-6 {
-				Col = append(Col, fmt.Sprintf("%d", int(v)))
-			} else {
-				Col = append(Col, fmt.Sprintf("%5f", A.Get(i, j)))
-			}
-
-		}
-		Cols = append(Cols, strings.Join(Col, ", "))
-	}
-	return fmt.Sprintf("[%s]", strings.Join(Cols, "; "))
-}
-
 // GetCol 获取列向量
 func (A Matrix) GetCol(j int) (V Matrix) {
 	shape := Shape{
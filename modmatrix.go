@@ -0,0 +1,408 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// ModMatrix 有限域 GF(p) 上的矩阵，p 为素数，元素取 [0, p) 内的整数，行优先存储
+type ModMatrix struct {
+	Shape
+	P     uint64
+	array []uint64
+}
+
+// NewModMatrix 默认构造方法，元素按模 p 取余，负数取非负余数。p 不是素数时 panic
+func NewModMatrix(shape Shape, p uint64, array []int64) ModMatrix {
+	if len(array) != shape.Size() {
+		panic(fmt.Sprintf("NewModMatrix: %d elements for shape %v.", len(array), shape))
+	}
+	A := ModZeros(shape, p)
+	for i, v := range array {
+		if v >= 0 {
+			A.array[i] = uint64(v) % p
+		} else {
+			// -(v+1) 不会溢出
+			A.array[i] = p - 1 - uint64(-(v+1))%p
+		}
+	}
+	return A
+}
+
+// ModZeros 零矩阵。p 不是素数时 panic
+func ModZeros(shape Shape, p uint64) ModMatrix {
+	if !new(big.Int).SetUint64(p).ProbablyPrime(20) {
+		panic(fmt.Sprintf("ModZeros: modulus %d is not prime.", p))
+	}
+	return modZeros(shape, p)
+}
+
+func modZeros(shape Shape, p uint64) ModMatrix {
+	return ModMatrix{Shape: shape, P: p, array: make([]uint64, shape.Size())}
+}
+
+// ModEye 单位矩阵
+func ModEye(n int, p uint64) ModMatrix {
+	A := ModZeros(Shape{n, n}, p)
+	for i := 0; i < n; i++ {
+		A.array[i*n+i] = 1
+	}
+	return A
+}
+
+// ModFromMatrix 将整数值的浮点矩阵转换到 GF(p)，元素不是整数时 panic
+func ModFromMatrix(A Matrix, p uint64) ModMatrix {
+	array := make([]int64, A.Size())
+	for i := range array {
+		v := A.GetIndex(i)
+		if v != math.Trunc(v) || math.Abs(v) >= 1<<63 {
+			panic(fmt.Sprintf("ModFromMatrix(A): element %d is not an integer: %v.", i, v))
+		}
+		array[i] = int64(v)
+	}
+	return NewModMatrix(A.Shape, p, array)
+}
+
+// Matrix 转换为浮点矩阵，元素取 [0, p) 内的代表元
+func (A ModMatrix) Matrix() Matrix {
+	M := Zeros(A.Shape)
+	for i, v := range A.array {
+		M.SetIndex(i, float64(v))
+	}
+	return M
+}
+
+// Get 获取元素
+func (A ModMatrix) Get(i, j int) uint64 {
+	return A.array[A.index(i, j)]
+}
+
+// Set 设置元素，v 按模 p 取余
+func (A ModMatrix) Set(i, j int, v uint64) {
+	A.array[A.index(i, j)] = v % A.P
+}
+
+func (A ModMatrix) index(i, j int) int {
+	if i >= A.Row || j >= A.Col || i < 0 || j < 0 {
+		panic(fmt.Sprintf("index out of bounds: (%d, %d) in %v", i, j, A.Shape))
+	}
+	return i*A.Col + j
+}
+
+// Copy 深拷贝
+func (A ModMatrix) Copy() ModMatrix {
+	S := A
+	S.array = append([]uint64(nil), A.array...)
+	return S
+}
+
+// Equal 判断两矩阵是否相等，模数不同视为不等
+func (A ModMatrix) Equal(B ModMatrix) bool {
+	if ShapeNotEqual(A.Shape, B.Shape) || A.P != B.P {
+		return false
+	}
+	for i, v := range A.array {
+		if v != B.array[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// String 以 [a, b; c, d] 形式输出
+func (A ModMatrix) String() string {
+	rows := make([]string, A.Row)
+	cells := make([]string, A.Col)
+	for i := 0; i < A.Row; i++ {
+		for j := 0; j < A.Col; j++ {
+			cells[j] = strconv.FormatUint(A.array[i*A.Col+j], 10)
+		}
+		rows[i] = strings.Join(cells, ", ")
+	}
+	return "[" + strings.Join(rows, "; ") + "]"
+}
+
+func (A ModMatrix) check(B ModMatrix, op string) {
+	if A.P != B.P {
+		panic(fmt.Sprintf("two matrix cannot [%s]. modulus %d x %d", op, A.P, B.P))
+	}
+}
+
+// Add 矩阵相加
+func (A ModMatrix) Add(B ModMatrix) ModMatrix {
+	A.check(B, "add")
+	if ShapeNotEqual(A.Shape, B.Shape) {
+		panic(fmt.Sprintf("two matrix cannot [add]. %v x %v", A.Shape, B.Shape))
+	}
+	S := modZeros(A.Shape, A.P)
+	for i := range S.array {
+		S.array[i] = addMod(A.array[i], B.array[i], A.P)
+	}
+	return S
+}
+
+// Sub 矩阵相减
+func (A ModMatrix) Sub(B ModMatrix) ModMatrix {
+	A.check(B, "sub")
+	if ShapeNotEqual(A.Shape, B.Shape) {
+		panic(fmt.Sprintf("two matrix cannot [sub]. %v x %v", A.Shape, B.Shape))
+	}
+	S := modZeros(A.Shape, A.P)
+	for i := range S.array {
+		S.array[i] = subMod(A.array[i], B.array[i], A.P)
+	}
+	return S
+}
+
+// ScaleMul 矩阵比例乘
+func (A ModMatrix) ScaleMul(k uint64) ModMatrix {
+	S := A.Copy()
+	k %= A.P
+	for i, v := range S.array {
+		S.array[i] = mulMod(v, k, A.P)
+	}
+	return S
+}
+
+// Dot 矩阵乘法
+func (A ModMatrix) Dot(B ModMatrix) ModMatrix {
+	A.check(B, "dot")
+	if A.Col != B.Row {
+		panic(fmt.Sprintf("two matrix cannot [dot]. %v x %v", A.Shape, B.Shape))
+	}
+	S := modZeros(Shape{A.Row, B.Col}, A.P)
+	for i := 0; i < A.Row; i++ {
+		for k := 0; k < A.Col; k++ {
+			a := A.array[i*A.Col+k]
+			if a == 0 {
+				continue
+			}
+			for j := 0; j < B.Col; j++ {
+				s := &S.array[i*S.Col+j]
+				*s = addMod(*s, mulMod(a, B.array[k*B.Col+j], A.P), A.P)
+			}
+		}
+	}
+	return S
+}
+
+// T 转置
+func (A ModMatrix) T() ModMatrix {
+	S := modZeros(Shape{A.Col, A.Row}, A.P)
+	for i := 0; i < A.Row; i++ {
+		for j := 0; j < A.Col; j++ {
+			S.array[j*S.Col+i] = A.array[i*A.Col+j]
+		}
+	}
+	return S
+}
+
+// Det 行列式
+func (A ModMatrix) Det() uint64 {
+	if A.Row != A.Col {
+		panic("Det(): matrix must be square.")
+	}
+	M := A.Copy()
+	n, p := A.Row, A.P
+	det := uint64(1)
+	for j := 0; j < n; j++ {
+		r := j
+		for r < n && M.array[r*n+j] == 0 {
+			r++
+		}
+		if r == n {
+			return 0
+		}
+		if r != j {
+			M.swapRows(r, j)
+			det = subMod(0, det, p)
+		}
+		pivot := M.array[j*n+j]
+		det = mulMod(det, pivot, p)
+		inv := invMod(pivot, p)
+		for i := j + 1; i < n; i++ {
+			if c := mulMod(M.array[i*n+j], inv, p); c != 0 {
+				M.subRow(i, j, c, j)
+			}
+		}
+	}
+	return det
+}
+
+// RREF 高斯-约当消元求行最简形，pivots 为各主元所在列
+func (A ModMatrix) RREF() (R ModMatrix, pivots []int) {
+	R = A.Copy()
+	pivots = R.eliminate(R.Col)
+	return
+}
+
+// Rank 秩
+func (A ModMatrix) Rank() int {
+	_, pivots := A.RREF()
+	return len(pivots)
+}
+
+// Inv 逆矩阵，A 奇异时以 ErrSingular panic
+func (A ModMatrix) Inv() ModMatrix {
+	if A.Row != A.Col {
+		panic("Inv(): matrix must be square.")
+	}
+	M := A.augment(ModEye(A.Row, A.P))
+	if len(M.eliminate(A.Col)) < A.Row {
+		panic(ErrSingular)
+	}
+	return M.columns(A.Col, M.Col)
+}
+
+// NullSpace 零空间的一组基，每列为一个基向量，形状为 Col x (Col - Rank)
+func (A ModMatrix) NullSpace() ModMatrix {
+	R, pivots := A.RREF()
+	N := modZeros(Shape{A.Col, A.Col - len(pivots)}, A.P)
+	for k, f := range freeColumns(A.Col, pivots) {
+		N.array[f*N.Col+k] = 1
+		for i, c := range pivots {
+			N.array[c*N.Col+k] = subMod(0, R.array[i*R.Col+f], A.P)
+		}
+	}
+	return N
+}
+
+// Solve 求解线性方程组 AX = B，A 可以不是方阵。
+// 有多个解时自由变量取 0，无解时以 ErrNoSolution panic
+func (A ModMatrix) Solve(B ModMatrix) ModMatrix {
+	A.check(B, "solve")
+	if A.Row != B.Row {
+		panic(fmt.Sprintf("Solve(B): shape not match. %v x %v", A.Shape, B.Shape))
+	}
+	M := A.augment(B)
+	pivots := M.eliminate(A.Col)
+	for i := len(pivots); i < M.Row; i++ {
+		for j := A.Col; j < M.Col; j++ {
+			if M.array[i*M.Col+j] != 0 {
+				panic(ErrNoSolution)
+			}
+		}
+	}
+	X := modZeros(Shape{A.Col, B.Col}, A.P)
+	for i, c := range pivots {
+		copy(X.array[c*X.Col:(c+1)*X.Col], M.array[i*M.Col+A.Col:(i+1)*M.Col])
+	}
+	return X
+}
+
+// augment 横向拼接 [A B]
+func (A ModMatrix) augment(B ModMatrix) ModMatrix {
+	M := modZeros(Shape{A.Row, A.Col + B.Col}, A.P)
+	for i := 0; i < A.Row; i++ {
+		copy(M.array[i*M.Col:], A.array[i*A.Col:(i+1)*A.Col])
+		copy(M.array[i*M.Col+A.Col:], B.array[i*B.Col:(i+1)*B.Col])
+	}
+	return M
+}
+
+// columns 取 [from, to) 列
+func (A ModMatrix) columns(from, to int) ModMatrix {
+	S := modZeros(Shape{A.Row, to - from}, A.P)
+	for i := 0; i < A.Row; i++ {
+		copy(S.array[i*S.Col:(i+1)*S.Col], A.array[i*A.Col+from:i*A.Col+to])
+	}
+	return S
+}
+
+// eliminate 原地高斯-约当消元，只在前 ncols 列中选主元，返回主元所在列
+func (A ModMatrix) eliminate(ncols int) (pivots []int) {
+	r := 0
+	for j := 0; j < ncols && r < A.Row; j++ {
+		p := r
+		for p < A.Row && A.array[p*A.Col+j] == 0 {
+			p++
+		}
+		if p == A.Row {
+			continue
+		}
+		A.swapRows(r, p)
+
+		inv := invMod(A.array[r*A.Col+j], A.P)
+		row := A.array[r*A.Col : (r+1)*A.Col]
+		for k := j; k < A.Col; k++ {
+			row[k] = mulMod(row[k], inv, A.P)
+		}
+		for i := 0; i < A.Row; i++ {
+			if c := A.array[i*A.Col+j]; i != r && c != 0 {
+				A.subRow(i, r, c, j)
+			}
+		}
+		pivots = append(pivots, j)
+		r++
+	}
+	return
+}
+
+// subRow 第 i 行减去第 r 行的 c 倍，只处理 from 及之后的列
+func (A ModMatrix) subRow(i, r int, c uint64, from int) {
+	dst := A.array[i*A.Col : (i+1)*A.Col]
+	src := A.array[r*A.Col : (r+1)*A.Col]
+	for k := from; k < A.Col; k++ {
+		dst[k] = subMod(dst[k], mulMod(c, src[k], A.P), A.P)
+	}
+}
+
+// swapRows 原地交换两行
+func (A ModMatrix) swapRows(i, j int) {
+	if i == j {
+		return
+	}
+	for k := 0; k < A.Col; k++ {
+		A.array[i*A.Col+k], A.array[j*A.Col+k] = A.array[j*A.Col+k], A.array[i*A.Col+k]
+	}
+}
+
+// freeColumns 返回不是主元的列
+func freeColumns(n int, pivots []int) []int {
+	free := make([]int, 0, n-len(pivots))
+	k := 0
+	for j := 0; j < n; j++ {
+		if k < len(pivots) && pivots[k] == j {
+			k++
+			continue
+		}
+		free = append(free, j)
+	}
+	return free
+}
+
+func addMod(a, b, p uint64) uint64 {
+	s, carry := bits.Add64(a, b, 0)
+	if carry != 0 || s >= p {
+		s -= p
+	}
+	return s
+}
+
+func subMod(a, b, p uint64) uint64 {
+	if a >= b {
+		return a - b
+	}
+	return p - (b - a)
+}
+
+func mulMod(a, b, p uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	return bits.Rem64(hi, lo, p)
+}
+
+// invMod 由费马小定理求逆元 a^(p-2)
+func invMod(a, p uint64) uint64 {
+	r, e := uint64(1), p-2
+	for ; e > 0; e >>= 1 {
+		if e&1 == 1 {
+			r = mulMod(r, a, p)
+		}
+		a = mulMod(a, a, p)
+	}
+	return r
+}
@@ -0,0 +1,109 @@
+package matrix
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func randMod(r *rand.Rand, shape Shape, p uint64) ModMatrix {
+	A := ModZeros(shape, p)
+	for i := 0; i < A.Row; i++ {
+		for j := 0; j < A.Col; j++ {
+			A.Set(i, j, r.Uint64())
+		}
+	}
+	return A
+}
+
+func expectPanic(t *testing.T, name string, want error, f func()) {
+	t.Helper()
+	defer func() {
+		r := recover()
+		if r == nil {
+			t.Errorf("%s: expected panic", name)
+			return
+		}
+		if err, _ := r.(error); want != nil && !errors.Is(err, want) {
+			t.Errorf("%s: recovered %v, want %v", name, r, want)
+		}
+	}()
+	f()
+}
+
+func TestModMatrixArithmetic(t *testing.T) {
+	A := NewModMatrix(Shape{2, 2}, 7, []int64{1, 2, 3, 4})
+	if d := A.Det(); d != 5 {
+		t.Errorf("Det = %d, want 5", d)
+	}
+	if !A.Dot(A.Inv()).Equal(ModEye(2, 7)) {
+		t.Errorf("A * Inv(A) = %v", A.Dot(A.Inv()))
+	}
+	if s := A.Sub(A.ScaleMul(2)).String(); s != "[6, 5; 4, 3]" {
+		t.Errorf("A - 2A = %s", s)
+	}
+	if !A.Add(A.T()).Equal(NewModMatrix(Shape{2, 2}, 7, []int64{2, 5, 5, 1})) {
+		t.Errorf("A + A' = %v", A.Add(A.T()))
+	}
+
+	B := NewModMatrix(Shape{1, 3}, 5, []int64{-1, -5, math.MinInt64})
+	if B.Get(0, 0) != 4 || B.Get(0, 1) != 0 || B.Get(0, 2) != 2 {
+		t.Errorf("negative reduction: %v", B)
+	}
+	if !ModFromMatrix(Builder().Row().Link(-1, 12).Build(), 11).Equal(NewModMatrix(Shape{1, 2}, 11, []int64{10, 1})) {
+		t.Error("ModFromMatrix")
+	}
+
+	expectPanic(t, "composite modulus", nil, func() { ModZeros(Shape{1, 1}, 15) })
+	expectPanic(t, "mixed modulus", nil, func() { A.Add(ModEye(2, 5)) })
+	expectPanic(t, "singular", ErrSingular, func() {
+		NewModMatrix(Shape{2, 2}, 7, []int64{1, 2, 2, 4}).Inv()
+	})
+}
+
+func TestModMatrixLargePrime(t *testing.T) {
+	// 梅森素数 2^61-1，乘积超过 64 位
+	const p = 1<<61 - 1
+	r := rand.New(rand.NewSource(1))
+	A := randMod(r, Shape{6, 6}, p)
+	if A.Det() == 0 {
+		t.Skip("random matrix is singular")
+	}
+	Ai := A.Inv()
+	if !A.Dot(Ai).Equal(ModEye(6, p)) || !Ai.Dot(A).Equal(ModEye(6, p)) {
+		t.Fatal("A * Inv(A) != I")
+	}
+	// det(AB) = det(A) det(B)
+	B := randMod(r, Shape{6, 6}, p)
+	if A.Dot(B).Det() != mulMod(A.Det(), B.Det(), p) {
+		t.Error("Det is not multiplicative")
+	}
+}
+
+func TestModMatrixNullSpaceSolve(t *testing.T) {
+	A := NewModMatrix(Shape{3, 5}, 11, []int64{
+		1, 2, 3, 4, 5,
+		2, 4, 6, 8, 10,
+		0, 1, 0, 1, 7,
+	})
+	R, pivots := A.RREF()
+	if A.Rank() != 2 || len(pivots) != 2 || pivots[0] != 0 || pivots[1] != 1 {
+		t.Fatalf("RREF = %v pivots %v", R, pivots)
+	}
+
+	N := A.NullSpace()
+	if N.Shape != (Shape{5, 3}) || !A.Dot(N).Equal(modZeros(Shape{3, 3}, 11)) || N.Rank() != 3 {
+		t.Errorf("NullSpace = %v", N)
+	}
+
+	B := A.Dot(NewModMatrix(Shape{5, 1}, 11, []int64{1, 2, 3, 4, 5}))
+	X := A.Solve(B)
+	if !A.Dot(X).Equal(B) {
+		t.Errorf("Solve: A X = %v, want %v", A.Dot(X), B)
+	}
+
+	expectPanic(t, "inconsistent", ErrNoSolution, func() {
+		A.Solve(NewModMatrix(Shape{3, 1}, 11, []int64{1, 1, 0}))
+	})
+}
@@ -0,0 +1,345 @@
+package matrix
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// npyMagic .npy 文件的魔数
+const npyMagic = "\x93NUMPY"
+
+// ReadNPY 读取 NumPy .npy 数组。
+// 支持 float64/float32/有符号与无符号整数/bool，C 或 Fortran 顺序；
+// 一维数组映射为行向量，零维数组映射为 1x1 矩阵
+func ReadNPY(r io.Reader) (Matrix, error) {
+	magic := make([]byte, len(npyMagic)+2)
+	if _, err := io.ReadFull(r, magic); err != nil {
+		return Matrix{}, fmt.Errorf("matrix: npy: %v", err)
+	}
+	if string(magic[:len(npyMagic)]) != npyMagic {
+		return Matrix{}, fmt.Errorf("matrix: npy: invalid magic")
+	}
+
+	var headerLen int
+	switch major := magic[len(npyMagic)]; major {
+	case 1:
+		var n uint16
+		if err := binary.Read(r, binary.LittleEndian, &n); err != nil {
+			return Matrix{}, fmt.Errorf("matrix: npy: %v", err)
+		}
+		headerLen = int(n)
+	case 2, 3:
+		var n uint32
+		if err := binary.Read(r, binary.LittleEndian, &n); err != nil {
+			return Matrix{}, fmt.Errorf("matrix: npy: %v", err)
+		}
+		headerLen = int(n)
+	default:
+		return Matrix{}, fmt.Errorf("matrix: npy: unsupported version %d", major)
+	}
+
+	header := make([]byte, headerLen)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return Matrix{}, fmt.Errorf("matrix: npy: %v", err)
+	}
+	descr, fortran, dims, err := parseNPYHeader(string(header))
+	if err != nil {
+		return Matrix{}, fmt.Errorf("matrix: npy: %v", err)
+	}
+
+	var shape Shape
+	switch len(dims) {
+	case 0:
+		shape = Shape{1, 1}
+	case 1:
+		shape = Shape{1, dims[0]}
+	case 2:
+		shape = Shape{dims[0], dims[1]}
+	default:
+		return Matrix{}, fmt.Errorf("matrix: npy: %d-d array is not supported", len(dims))
+	}
+	if !decodeShapeOK(uint64(shape.Row), uint64(shape.Col)) {
+		return Matrix{}, fmt.Errorf("matrix: npy: shape %v too large", dims)
+	}
+
+	data, err := readNPYData(r, descr, shape.Size())
+	if err != nil {
+		return Matrix{}, fmt.Errorf("matrix: npy: %v", err)
+	}
+
+	if fortran && len(dims) == 2 {
+		// 列优先转行优先
+		A := Zeros(shape)
+		for j := 0; j < shape.Col; j++ {
+			for i := 0; i < shape.Row; i++ {
+				A.Set(i, j, data[j*shape.Row+i])
+			}
+		}
+		return A, nil
+	}
+	return NewMatrix(shape, data), nil
+}
+
+// npyItemSize 各 dtype 的字节数
+var npyItemSize = map[string]int{
+	"f8": 8, "f4": 4,
+	"i8": 8, "i4": 4, "i2": 2, "i1": 1,
+	"u8": 8, "u4": 4, "u2": 2, "u1": 1,
+	"b1": 1,
+}
+
+// readNPYData 按 dtype 描述读取 n 个元素并转为 float64。
+// 分块读取，截断的输入不会导致按声明的形状一次性分配内存
+func readNPYData(r io.Reader, descr string, n int) ([]float64, error) {
+	if len(descr) < 3 {
+		return nil, fmt.Errorf("invalid descr %q", descr)
+	}
+	var order binary.ByteOrder
+	switch descr[0] {
+	case '<', '|', '=':
+		order = binary.LittleEndian
+	case '>':
+		order = binary.BigEndian
+	default:
+		return nil, fmt.Errorf("invalid descr %q", descr)
+	}
+	kind := descr[1:]
+	size, ok := npyItemSize[kind]
+	if !ok {
+		return nil, fmt.Errorf("unsupported dtype %q", descr)
+	}
+
+	data := make([]float64, 0)
+	chunk := make([]byte, 8*1024)
+	for remain := n; remain > 0; {
+		m := remain
+		if m > len(chunk)/size {
+			m = len(chunk) / size
+		}
+		if _, err := io.ReadFull(r, chunk[:m*size]); err != nil {
+			return nil, err
+		}
+		for i := 0; i < m; i++ {
+			b := chunk[i*size : (i+1)*size]
+			var v float64
+			switch kind {
+			case "f8":
+				v = math.Float64frombits(order.Uint64(b))
+			case "f4":
+				v = float64(math.Float32frombits(order.Uint32(b)))
+			case "i8":
+				v = float64(int64(order.Uint64(b)))
+			case "i4":
+				v = float64(int32(order.Uint32(b)))
+			case "i2":
+				v = float64(int16(order.Uint16(b)))
+			case "i1":
+				v = float64(int8(b[0]))
+			case "u8":
+				v = float64(order.Uint64(b))
+			case "u4":
+				v = float64(order.Uint32(b))
+			case "u2":
+				v = float64(order.Uint16(b))
+			case "u1", "b1":
+				v = float64(b[0])
+			}
+			data = append(data, v)
+		}
+		remain -= m
+	}
+	return data, nil
+}
+
+// parseNPYHeader 解析形如 {'descr': '<f8', 'fortran_order': False, 'shape': (3, 4), } 的头部
+func parseNPYHeader(s string) (descr string, fortran bool, dims []int, err error) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "{") || !strings.HasSuffix(s, "}") {
+		return "", false, nil, fmt.Errorf("invalid header %q", s)
+	}
+	s = s[1 : len(s)-1]
+
+	seen := map[string]bool{}
+	for len(strings.TrimSpace(s)) > 0 {
+		s = strings.TrimSpace(s)
+		key, rest, ok := npyQuoted(s)
+		if !ok {
+			return "", false, nil, fmt.Errorf("invalid header key near %q", s)
+		}
+		rest = strings.TrimSpace(rest)
+		if !strings.HasPrefix(rest, ":") {
+			return "", false, nil, fmt.Errorf("missing ':' after %q", key)
+		}
+		rest = strings.TrimSpace(rest[1:])
+
+		// 值到下一个顶层逗号为止
+		depth, end := 0, len(rest)
+		for i, c := range rest {
+			if c == '(' {
+				depth++
+			} else if c == ')' {
+				depth--
+			} else if c == ',' && depth == 0 {
+				end = i
+				break
+			}
+		}
+		value := strings.TrimSpace(rest[:end])
+		s = rest[end:]
+		if strings.HasPrefix(s, ",") {
+			s = s[1:]
+		}
+
+		seen[key] = true
+		switch key {
+		case "descr":
+			d, _, ok := npyQuoted(value)
+			if !ok {
+				return "", false, nil, fmt.Errorf("invalid descr %s", value)
+			}
+			descr = d
+		case "fortran_order":
+			switch value {
+			case "True":
+				fortran = true
+			case "False":
+				fortran = false
+			default:
+				return "", false, nil, fmt.Errorf("invalid fortran_order %s", value)
+			}
+		case "shape":
+			if !strings.HasPrefix(value, "(") || !strings.HasSuffix(value, ")") {
+				return "", false, nil, fmt.Errorf("invalid shape %s", value)
+			}
+			for _, f := range strings.Split(value[1:len(value)-1], ",") {
+				f = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(f), "L"))
+				if f == "" {
+					continue
+				}
+				n, err := strconv.Atoi(f)
+				if err != nil || n < 0 {
+					return "", false, nil, fmt.Errorf("invalid shape %s", value)
+				}
+				dims = append(dims, n)
+			}
+		}
+	}
+
+	for _, key := range []string{"descr", "fortran_order", "shape"} {
+		if !seen[key] {
+			return "", false, nil, fmt.Errorf("header missing %q", key)
+		}
+	}
+	return descr, fortran, dims, nil
+}
+
+// npyQuoted 解析开头的单引号或双引号字符串
+func npyQuoted(s string) (value, rest string, ok bool) {
+	if len(s) < 2 || (s[0] != '\'' && s[0] != '"') {
+		return "", s, false
+	}
+	end := strings.IndexByte(s[1:], s[0])
+	if end < 0 {
+		return "", s, false
+	}
+	return s[1 : end+1], s[end+2:], true
+}
+
+// WriteNPY 以 float64、C 顺序写出二维 .npy 数组（格式版本 1.0），向量也写为二维，
+// 需要一维数组时使用 WriteNPYVector
+func WriteNPY(w io.Writer, A Matrix) error {
+	return writeNPY(w, fmt.Sprintf("(%d, %d)", A.Row, A.Col), A)
+}
+
+// WriteNPYVector 将行向量或列向量写为形状 (n,) 的一维 .npy 数组，A 不是向量时 panic
+func WriteNPYVector(w io.Writer, A Matrix) error {
+	if !IsVector(A) {
+		panic(fmt.Sprintf("WriteNPYVector(w, A): A must be a vector, got %v.", A.Shape))
+	}
+	return writeNPY(w, fmt.Sprintf("(%d,)", A.Size()), A)
+}
+
+func writeNPY(w io.Writer, shape string, A Matrix) error {
+	header := fmt.Sprintf("{'descr': '<f8', 'fortran_order': False, 'shape': %s, }", shape)
+	// 头部以换行结束，并用空格补齐使数据按 64 字节对齐
+	total := len(npyMagic) + 2 + 2 + len(header) + 1
+	if pad := total % 64; pad != 0 {
+		header += strings.Repeat(" ", 64-pad)
+	}
+	header += "\n"
+
+	var buf bytes.Buffer
+	buf.WriteString(npyMagic)
+	buf.Write([]byte{1, 0})
+	binary.Write(&buf, binary.LittleEndian, uint16(len(header)))
+	buf.WriteString(header)
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		return err
+	}
+
+	data := make([]byte, 8*A.Size())
+	for i := 0; i < A.Size(); i++ {
+		binary.LittleEndian.PutUint64(data[8*i:], math.Float64bits(A.GetIndex(i)))
+	}
+	_, err := w.Write(data)
+	return err
+}
+
+// ReadNPZ 读取 .npz 归档（存储或 deflate 压缩），返回以数组名（去掉 .npy 后缀）为键的矩阵
+func ReadNPZ(r io.ReaderAt, size int64) (map[string]Matrix, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("matrix: npz: %v", err)
+	}
+
+	arrays := make(map[string]Matrix, len(zr.File))
+	for _, f := range zr.File {
+		if !strings.HasSuffix(f.Name, ".npy") {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("matrix: npz: %s: %v", f.Name, err)
+		}
+		A, err := ReadNPY(rc)
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%v (in %s)", err, f.Name)
+		}
+		arrays[strings.TrimSuffix(f.Name, ".npy")] = A
+	}
+	return arrays, nil
+}
+
+// WriteNPZ 写出 .npz 归档，compress 为 true 时使用 deflate 压缩（即 numpy.savez_compressed）
+func WriteNPZ(w io.Writer, arrays map[string]Matrix, compress bool) error {
+	names := make([]string, 0, len(arrays))
+	for name := range arrays {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	method := zip.Store
+	if compress {
+		method = zip.Deflate
+	}
+
+	zw := zip.NewWriter(w)
+	for _, name := range names {
+		fw, err := zw.CreateHeader(&zip.FileHeader{Name: name + ".npy", Method: method})
+		if err != nil {
+			return err
+		}
+		if err := WriteNPY(fw, arrays[name]); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
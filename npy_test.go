@@ -0,0 +1,110 @@
+package matrix
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+// npyBytes 构造 .npy 文件内容
+func npyBytes(header string, order binary.ByteOrder, data interface{}) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(npyMagic)
+	buf.Write([]byte{1, 0})
+	binary.Write(&buf, binary.LittleEndian, uint16(len(header)+1))
+	buf.WriteString(header + "\n")
+	binary.Write(&buf, order, data)
+	return buf.Bytes()
+}
+
+func TestReadNPY(t *testing.T) {
+	Expected := Builder().Row().Link(1, 2, 3).Link(4, 5, 6).Build()
+
+	cases := [][]byte{
+		npyBytes("{'descr': '<f8', 'fortran_order': False, 'shape': (2, 3), }",
+			binary.LittleEndian, []float64{1, 2, 3, 4, 5, 6}),
+		npyBytes("{'descr': '<f4', 'fortran_order': True, 'shape': (2, 3), }",
+			binary.LittleEndian, []float32{1, 4, 2, 5, 3, 6}),
+		npyBytes("{'shape': (2, 3), 'fortran_order': False, 'descr': '>i4'}",
+			binary.BigEndian, []int32{1, 2, 3, 4, 5, 6}),
+		npyBytes("{'descr': '<i8', 'fortran_order': True, 'shape': (2, 3), }",
+			binary.LittleEndian, []int64{1, 4, 2, 5, 3, 6}),
+	}
+	for k, c := range cases {
+		A, err := ReadNPY(bytes.NewReader(c))
+		if err != nil || !MatrixEqual(A, Expected) {
+			t.Errorf("error method: ReadNPY case %d: %v %v", k, A, err)
+		}
+	}
+
+	V, err := ReadNPY(bytes.NewReader(npyBytes("{'descr': '<f8', 'fortran_order': False, 'shape': (3,), }",
+		binary.LittleEndian, []float64{1, 2, 3})))
+	if err != nil || !MatrixEqual(V, NewVector([]float64{1, 2, 3}, 2)) {
+		t.Error("error method: ReadNPY 1-d")
+	}
+
+	bad := npyBytes("{'descr': '<c16', 'fortran_order': False, 'shape': (1,), }", binary.LittleEndian, []float64{1, 2})
+	if _, err := ReadNPY(bytes.NewReader(bad)); err == nil {
+		t.Error("error method: ReadNPY accepts complex dtype")
+	}
+
+	// 头部声明巨大的形状或元素字节数，数据却很短
+	huge := []string{
+		"{'descr': '<f8', 'fortran_order': False, 'shape': (4294967296, 4294967296), }",
+		"{'descr': '<f8', 'fortran_order': False, 'shape': (1000000, 1000000), }",
+		"{'descr': '<f8', 'fortran_order': False, 'shape': (1000000000,), }",
+		"{'descr': '<f99999999', 'fortran_order': False, 'shape': (1000,), }",
+	}
+	for _, h := range huge {
+		if _, err := ReadNPY(bytes.NewReader(npyBytes(h, binary.LittleEndian, []float64{1, 2}))); err == nil {
+			t.Errorf("error method: ReadNPY accepts %s", h)
+		}
+	}
+}
+
+func TestWriteNPY(t *testing.T) {
+	A := Builder().Row().Link(1, 2.5).Link(-3, 4).Link(5, 6).Build()
+
+	var buf bytes.Buffer
+	if err := WriteNPY(&buf, A); err != nil {
+		t.Fatal(err)
+	}
+	if (buf.Len()-8*A.Size())%64 != 0 {
+		t.Error("error method: WriteNPY header alignment")
+	}
+	B, err := ReadNPY(&buf)
+	if err != nil || !MatrixEqual(A, B) {
+		t.Error("error method: WriteNPY round trip")
+	}
+
+	buf.Reset()
+	V := NewVector([]float64{1, 2, 3}, 1)
+	if err := WriteNPYVector(&buf, V); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte("'shape': (3,)")) {
+		t.Error("error method: WriteNPYVector shape")
+	}
+	B, err = ReadNPY(&buf)
+	if err != nil || !MatrixEqual(B, V.T()) {
+		t.Error("error method: WriteNPYVector round trip")
+	}
+}
+
+func TestNPZ(t *testing.T) {
+	arrays := map[string]Matrix{
+		"a": Builder().Row().Link(1, 2).Link(3, 4).Build(),
+		"b": NewVector([]float64{1, 2, 3}, 1),
+	}
+
+	for _, compress := range []bool{false, true} {
+		var buf bytes.Buffer
+		if err := WriteNPZ(&buf, arrays, compress); err != nil {
+			t.Fatal(err)
+		}
+		got, err := ReadNPZ(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil || len(got) != 2 || !MatrixEqual(got["a"], arrays["a"]) || !MatrixEqual(got["b"], arrays["b"]) {
+			t.Errorf("error method: NPZ round trip (compress = %v)", compress)
+		}
+	}
+}
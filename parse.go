@@ -0,0 +1,358 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ParseError 解析错误，Offset 为字节偏移，Line 与 Column 从 1 开始
+type ParseError struct {
+	Offset int
+	Line   int
+	Column int
+	Msg    string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("matrix: parse error at line %d, column %d: %s", e.Line, e.Column, e.Msg)
+}
+
+// Parse 解析矩阵字面量，接受 String 的输出及 Matlab 语法：
+//
+//	[1, 2; 3, 4]       String 的输出
+//	[1 2
+//	 3 4]              空格分隔元素，换行分隔行
+//	[1e-3 -Inf NaN]    科学计数法、Inf 与 NaN
+//	[1:0.5:3; 1:5]     区间 start:step:end 或 start:end
+//
+// 方括号可以省略，% 之后到行尾为注释
+func Parse(s string) (Matrix, error) {
+	p := &parser{lexer: lexer{src: s, line: 1, col: 1}}
+	p.next()
+	return p.parse()
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokNumber
+	tokLBrack
+	tokRBrack
+	tokComma
+	tokSemi
+	tokNewline
+	tokColon
+)
+
+func (k tokenKind) String() string {
+	return [...]string{"end of input", "number", "'['", "']'", "','", "';'", "newline", "':'"}[k]
+}
+
+var punctuation = map[rune]tokenKind{
+	'[': tokLBrack, ']': tokRBrack, ',': tokComma, ';': tokSemi, '\n': tokNewline, ':': tokColon,
+}
+
+type token struct {
+	kind  tokenKind
+	value float64
+	text  string
+	pos   position
+}
+
+type position struct {
+	offset, line, col int
+}
+
+type lexer struct {
+	src  string
+	off  int
+	line int
+	col  int
+}
+
+func (l *lexer) pos() position {
+	return position{l.off, l.line, l.col}
+}
+
+func (l *lexer) peek() rune {
+	if l.off >= len(l.src) {
+		return -1
+	}
+	r, _ := utf8.DecodeRuneInString(l.src[l.off:])
+	return r
+}
+
+func (l *lexer) advance() rune {
+	r, size := utf8.DecodeRuneInString(l.src[l.off:])
+	l.off += size
+	if r == '\n' {
+		l.line++
+		l.col = 1
+	} else {
+		l.col++
+	}
+	return r
+}
+
+func (l *lexer) errorf(pos position, format string, args ...interface{}) *ParseError {
+	return &ParseError{Offset: pos.offset, Line: pos.line, Column: pos.col, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (l *lexer) scan() (token, *ParseError) {
+	for {
+		r := l.peek()
+		if r == ' ' || r == '\t' || r == '\r' {
+			l.advance()
+			continue
+		}
+		if r == '%' {
+			for r != '\n' && r != -1 {
+				l.advance()
+				r = l.peek()
+			}
+			continue
+		}
+		break
+	}
+
+	pos := l.pos()
+	r := l.peek()
+	switch {
+	case r == -1:
+		return token{kind: tokEOF, pos: pos}, nil
+	case punctuation[r] != tokEOF:
+		l.advance()
+		return token{kind: punctuation[r], text: string(r), pos: pos}, nil
+	case r == '+' || r == '-' || r == '.' || (r >= '0' && r <= '9') || r == 'I' || r == 'i' || r == 'N' || r == 'n':
+		return l.scanNumber(pos)
+	default:
+		return token{}, l.errorf(pos, "unexpected character %q", r)
+	}
+}
+
+func (l *lexer) scanNumber(pos position) (token, *ParseError) {
+	start := l.off
+	if r := l.peek(); r == '+' || r == '-' {
+		l.advance()
+	}
+
+	// Inf / NaN
+	rest := l.src[l.off:]
+	for _, word := range []string{"inf", "nan"} {
+		if len(rest) >= 3 && strings.EqualFold(rest[:3], word) {
+			if len(rest) > 3 && isIdent(rune(rest[3])) {
+				break
+			}
+			for i := 0; i < 3; i++ {
+				l.advance()
+			}
+			text := l.src[start:l.off]
+			v := math.NaN()
+			if word == "inf" {
+				v = math.Inf(1)
+				if text[0] == '-' {
+					v = math.Inf(-1)
+				}
+			}
+			return token{kind: tokNumber, value: v, text: text, pos: pos}, nil
+		}
+	}
+
+	digits := 0
+	for isDigit(l.peek()) {
+		l.advance()
+		digits++
+	}
+	if l.peek() == '.' {
+		l.advance()
+		for isDigit(l.peek()) {
+			l.advance()
+			digits++
+		}
+	}
+	if digits == 0 {
+		return token{}, l.errorf(pos, "expected number, got %q", l.src[start:l.off])
+	}
+	if r := l.peek(); r == 'e' || r == 'E' {
+		ePos := l.pos()
+		l.advance()
+		if r := l.peek(); r == '+' || r == '-' {
+			l.advance()
+		}
+		if !isDigit(l.peek()) {
+			return token{}, l.errorf(ePos, "malformed exponent in %q", l.src[start:l.off])
+		}
+		for isDigit(l.peek()) {
+			l.advance()
+		}
+	}
+	if r := l.peek(); isIdent(r) || r == '.' {
+		return token{}, l.errorf(l.pos(), "unexpected character %q after number", r)
+	}
+
+	text := l.src[start:l.off]
+	v, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return token{}, l.errorf(pos, "invalid number %q", text)
+	}
+	return token{kind: tokNumber, value: v, text: text, pos: pos}, nil
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+func isIdent(r rune) bool {
+	return r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || isDigit(r)
+}
+
+type parser struct {
+	lexer
+	tok token
+	err *ParseError
+}
+
+func (p *parser) next() {
+	if p.err != nil {
+		return
+	}
+	tok, err := p.scan()
+	if err != nil {
+		p.err = err
+		tok = token{kind: tokEOF, pos: position{err.Offset, err.Line, err.Column}}
+	}
+	p.tok = tok
+}
+
+func (p *parser) parse() (Matrix, error) {
+	bracket := p.tok.kind == tokLBrack
+	open := p.tok.pos
+	if bracket {
+		p.next()
+	}
+
+	var array []float64
+	shape := Shape{}
+	for p.err == nil {
+		// 跳过空行
+		for p.tok.kind == tokSemi || p.tok.kind == tokNewline {
+			p.next()
+		}
+		if p.tok.kind != tokNumber {
+			break
+		}
+
+		rowPos := p.tok.pos
+		row := p.row()
+		if p.err != nil {
+			break
+		}
+		if shape.Row == 0 {
+			shape.Col = len(row)
+		} else if len(row) != shape.Col {
+			p.err = p.errorf(rowPos, "row %d has %d elements, expect %d", shape.Row+1, len(row), shape.Col)
+			break
+		}
+		array = append(array, row...)
+		shape.Row++
+	}
+	if p.err != nil {
+		return Matrix{}, p.err
+	}
+
+	if bracket {
+		if p.tok.kind != tokRBrack {
+			if p.tok.kind == tokEOF {
+				return Matrix{}, p.errorf(open, "unclosed '['")
+			}
+			return Matrix{}, p.errorf(p.tok.pos, "unexpected %s", p.tok.kind)
+		}
+		p.next()
+		for p.tok.kind == tokNewline || p.tok.kind == tokSemi {
+			p.next()
+		}
+	}
+	if p.err != nil {
+		return Matrix{}, p.err
+	}
+	if p.tok.kind != tokEOF {
+		return Matrix{}, p.errorf(p.tok.pos, "unexpected %s", p.tok.kind)
+	}
+
+	if shape.Row == 0 {
+		shape.Col = 0
+	}
+	return NewMatrix(shape, array), nil
+}
+
+// row 解析一行元素，元素之间的逗号可省略
+func (p *parser) row() []float64 {
+	row := []float64{}
+	for p.err == nil && p.tok.kind == tokNumber {
+		row = append(row, p.element()...)
+		if p.tok.kind == tokComma {
+			p.next()
+		}
+	}
+	return row
+}
+
+// element 解析数字或区间 start:end、start:step:end
+func (p *parser) element() []float64 {
+	pos := p.tok.pos
+	vs := []float64{p.tok.value}
+	p.next()
+	for p.err == nil && p.tok.kind == tokColon {
+		colon := p.tok.pos
+		p.next()
+		if p.tok.kind != tokNumber {
+			p.err = p.errorf(p.tok.pos, "expected number after ':', got %s", p.tok.kind)
+			return nil
+		}
+		vs = append(vs, p.tok.value)
+		p.next()
+		if len(vs) > 3 {
+			p.err = p.errorf(colon, "range takes at most 3 parts")
+			return nil
+		}
+	}
+
+	if len(vs) == 1 {
+		return vs
+	}
+	start, step, end := vs[0], 1.0, vs[len(vs)-1]
+	if len(vs) == 3 {
+		step = vs[1]
+	}
+	for _, v := range vs {
+		if math.IsInf(v, 0) || math.IsNaN(v) {
+			p.err = p.errorf(pos, "range bounds must be finite")
+			return nil
+		}
+	}
+	if step != 0 && math.Abs((end-start)/step) > maxRangeSize {
+		p.err = p.errorf(pos, "range has more than %d elements", maxRangeSize)
+		return nil
+	}
+	return expandRange(start, step, end)
+}
+
+// maxRangeSize 区间展开的最大元素个数
+const maxRangeSize = 1 << 24
+
+// expandRange 按 Matlab 语义展开区间，步长为 0 或方向相反时为空
+func expandRange(start, step, end float64) []float64 {
+	if step == 0 || (step > 0 && start > end) || (step < 0 && start < end) {
+		return []float64{}
+	}
+	n := int(math.Floor((end-start)/step+1e-10)) + 1
+	vs := make([]float64, n)
+	for k := range vs {
+		vs[k] = start + float64(k)*step
+	}
+	return vs
+}
@@ -0,0 +1,68 @@
+package matrix
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	A := Builder().Row().Link(1, -0.5).Link(2.25, 1000).Build()
+
+	cases := []string{
+		A.String(),
+		fmt.Sprintf("%+v", A),
+		fmt.Sprintf("%.3e", A),
+		"[1 -0.5; 2.25 1e3]",
+		"[1, -.5,\n 2.25, 1000,]",
+		"  1 -0.5 % first row\n 2.25 1000\n",
+		"[\n  1  -0.5\n\n  2.25  1e+3\n];",
+	}
+	for _, s := range cases {
+		B, err := Parse(s)
+		if err != nil || !MatrixEqual(A, B) {
+			t.Errorf("error method: Parse(%q): %v %v", s, B, err)
+		}
+	}
+
+	B, err := Parse("[1:0.5:3; 5:-1:1; 0 1:3 Inf]")
+	if err != nil || B.Shape != (Shape{3, 5}) || B.Get(0, 4) != 3 || B.Get(1, 4) != 1 || !math.IsInf(B.Get(2, 4), 1) {
+		t.Errorf("error method: Parse range, got %v %v", B, err)
+	}
+
+	C, err := Parse("[nan -inf]")
+	if err != nil || !math.IsNaN(C.Get(0, 0)) || !math.IsInf(C.Get(0, 1), -1) {
+		t.Error("error method: Parse NaN/Inf")
+	}
+
+	E, err := Parse("[]")
+	if err != nil || E.Shape != (Shape{0, 0}) {
+		t.Error("error method: Parse empty")
+	}
+}
+
+func TestParseError(t *testing.T) {
+	cases := []struct {
+		input  string
+		line   int
+		column int
+	}{
+		{"[1 2; 3]", 1, 7},
+		{"[1 2\n 3 x]", 2, 4},
+		{"[1 2e]", 1, 5},
+		{"[1 2", 1, 1},
+		{"[1 2]]", 1, 6},
+		{"[1:Inf]", 1, 2},
+		{"[1,,2]", 1, 4},
+		{"[1.2.3]", 1, 5},
+	}
+
+	for _, c := range cases {
+		_, err := Parse(c.input)
+		var e *ParseError
+		if !errors.As(err, &e) || e.Line != c.line || e.Column != c.column {
+			t.Errorf("error method: Parse(%q) error %v", c.input, err)
+		}
+	}
+}
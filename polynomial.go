@@ -8,28 +8,10 @@ const (
 	maxTryCount = 10_000
 )
 
-// Conv 多项式乘法（离散卷积)
+// Conv 多项式乘法（离散卷积)，序列较长时自动采用 FFT 计算
 func Conv(F, G Matrix) (Y Matrix) {
-	l := F.Size() + G.Size() - 1
-	if F.Row == 1 && G.Row == 1 {
-		Y = Zeros(Shape{1, l})
-	} else if F.Col == 1 && G.Col == 1 {
-		Y = Zeros(Shape{l, 1})
-	} else {
-		panic("conv only support vector")
-	}
-
-	for i := 0; i < l; i++ {
-		v := 0.0
-		for j := 0; j < G.Size(); j++ {
-			if i >= j && (i-j) < F.Size() {
-				v += F.GetIndex(i-j) * G.GetIndex(j)
-			}
-
-		}
-		Y.SetIndex(i, v)
-	}
-	return
+	f, g := convOperands("conv", F, G)
+	return convResult(F, G, convolve(f, g), len(f), len(g), ConvFull)
 }
 
 // Diff 多项式差分
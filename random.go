@@ -0,0 +1,89 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// Rand [0, 1) 均匀分布随机矩阵
+func Rand(shape Shape, src rand.Source) Matrix {
+	r := rand.New(src)
+	A := Zeros(shape)
+	for i := 0; i < A.Size(); i++ {
+		A.SetIndex(i, r.Float64())
+	}
+	return A
+}
+
+// Randn 标准正态分布随机矩阵
+func Randn(shape Shape, src rand.Source) Matrix {
+	r := rand.New(src)
+	A := Zeros(shape)
+	for i := 0; i < A.Size(); i++ {
+		A.SetIndex(i, r.NormFloat64())
+	}
+	return A
+}
+
+// RandInt [low, high) 均匀分布随机整数矩阵
+func RandInt(shape Shape, low, high int, src rand.Source) Matrix {
+	if high <= low {
+		panic(fmt.Sprintf("RandInt: high must > low, got [%d, %d).", low, high))
+	}
+	r := rand.New(src)
+	A := Zeros(shape)
+	for i := 0; i < A.Size(); i++ {
+		A.SetIndex(i, float64(low+r.Intn(high-low)))
+	}
+	return A
+}
+
+// RandOrthogonal 服从 Haar 分布的 n 阶随机正交矩阵。
+// 对高斯随机矩阵做 QR 分解，QR 得到的 R 对角元为正，因此 Q 的分布是均匀的
+func RandOrthogonal(n int, src rand.Source) Matrix {
+	Q, _ := QR(Randn(Shape{n, n}, src))
+	return Q
+}
+
+// RandSPD n 阶随机对称正定矩阵，特征值在 [1, 2) 内均匀分布
+func RandSPD(n int, src rand.Source) Matrix {
+	r := rand.New(src)
+	d := make([]float64, n)
+	for i := range d {
+		d[i] = 1 + r.Float64()
+	}
+	Q := RandOrthogonal(n, r)
+	S := Q.Dot(Diag(d)).Dot(Q.T())
+
+	// 消除舍入误差带来的不对称
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			v := (S.Get(i, j) + S.Get(j, i)) / 2
+			S.Set(i, j, v)
+			S.Set(j, i, v)
+		}
+	}
+	return S
+}
+
+// RandCond 2-范数条件数为 cond 的 n 阶随机矩阵，奇异值在 [1/cond, 1] 内按几何级数分布
+func RandCond(n int, cond float64, src rand.Source) Matrix {
+	if cond < 1 {
+		panic(fmt.Sprintf("RandCond: cond must >= 1, got %g.", cond))
+	}
+	r := rand.New(src)
+
+	d := make([]float64, n)
+	for i := range d {
+		if n == 1 {
+			d[i] = 1
+			continue
+		}
+		d[i] = math.Pow(cond, -float64(i)/float64(n-1))
+	}
+
+	U := RandOrthogonal(n, r)
+	V := RandOrthogonal(n, r)
+	return U.Dot(Diag(d)).Dot(V.T())
+}
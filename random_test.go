@@ -0,0 +1,58 @@
+package matrix
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestRand(t *testing.T) {
+	shape := Shape{3, 4}
+	A := Rand(shape, rand.NewSource(1))
+	B := Rand(shape, rand.NewSource(1))
+
+	if !MatrixEqual(A, B) {
+		t.Error("error method: Rand is not reproducible")
+	}
+	for i := 0; i < A.Size(); i++ {
+		if A.GetIndex(i) < 0 || A.GetIndex(i) >= 1 {
+			t.Error("error method: Rand")
+		}
+	}
+
+	C := RandInt(shape, -2, 3, rand.NewSource(2))
+	for i := 0; i < C.Size(); i++ {
+		v := C.GetIndex(i)
+		if v < -2 || v >= 3 || v != math.Trunc(v) {
+			t.Error("error method: RandInt")
+		}
+	}
+
+	if !MatrixEqual(Randn(shape, rand.NewSource(3)), Randn(shape, rand.NewSource(3))) {
+		t.Error("error method: Randn is not reproducible")
+	}
+}
+
+func TestRandStructured(t *testing.T) {
+	src := rand.NewSource(42)
+
+	Q := RandOrthogonal(4, src)
+	if !MatrixEqual(Q.T().Dot(Q), Eye(4)) {
+		t.Error("error method: RandOrthogonal")
+	}
+
+	S := RandSPD(4, src)
+	if !MatrixEqual(S, S.T()) {
+		t.Error("error method: RandSPD symmetric")
+	}
+	D, _ := EigSym(S)
+	if D.GetIndex(3) < 1-1e-9 || D.GetIndex(0) >= 2 {
+		t.Error("error method: RandSPD eigenvalues")
+	}
+
+	A := RandCond(5, 100, src)
+	D, _ = EigSym(A.T().Dot(A))
+	if math.Abs(math.Sqrt(D.GetIndex(0)/D.GetIndex(4))-100) > 1e-6 {
+		t.Error("error method: RandCond")
+	}
+}
@@ -0,0 +1,318 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+)
+
+// RatMatrix 以 *big.Rat 为元素的精确有理数矩阵，行优先存储。
+// 所有运算都返回新矩阵，不修改参数，Get 返回元素的副本
+type RatMatrix struct {
+	Shape
+	array []*big.Rat
+}
+
+// NewRatMatrix 默认构造方法，array 为 nil 的元素视为 0，元素会被复制
+func NewRatMatrix(shape Shape, array []*big.Rat) (A RatMatrix) {
+	if len(array) != shape.Size() {
+		panic(fmt.Sprintf("NewRatMatrix: %d elements for shape %v.", len(array), shape))
+	}
+	A = RatZeros(shape)
+	for i, v := range array {
+		if v != nil {
+			A.array[i].Set(v)
+		}
+	}
+	return
+}
+
+// RatZeros 零矩阵
+func RatZeros(shape Shape) (A RatMatrix) {
+	A.Shape = shape
+	A.array = make([]*big.Rat, shape.Size())
+	for i := range A.array {
+		A.array[i] = new(big.Rat)
+	}
+	return
+}
+
+// RatEye 单位矩阵
+func RatEye(n int) RatMatrix {
+	A := RatZeros(Shape{n, n})
+	for i := 0; i < n; i++ {
+		A.array[i*n+i].SetInt64(1)
+	}
+	return A
+}
+
+// RatFromMatrix 将浮点矩阵精确转换为有理数矩阵，元素为 NaN 或 ±Inf 时 panic
+func RatFromMatrix(A Matrix) RatMatrix {
+	R := RatZeros(A.Shape)
+	for i := range R.array {
+		v := A.GetIndex(i)
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			panic(fmt.Sprintf("RatFromMatrix(A): element %d is %v.", i, v))
+		}
+		R.array[i].SetFloat64(v)
+	}
+	return R
+}
+
+// Matrix 转换为最接近的浮点矩阵
+func (A RatMatrix) Matrix() Matrix {
+	M := Zeros(A.Shape)
+	for i, v := range A.array {
+		f, _ := v.Float64()
+		M.SetIndex(i, f)
+	}
+	return M
+}
+
+// Get 获取元素的副本
+func (A RatMatrix) Get(i, j int) *big.Rat {
+	return new(big.Rat).Set(A.at(i, j))
+}
+
+// Set 设置元素，v 会被复制
+func (A RatMatrix) Set(i, j int, v *big.Rat) {
+	A.at(i, j).Set(v)
+}
+
+func (A RatMatrix) at(i, j int) *big.Rat {
+	if i >= A.Row || j >= A.Col || i < 0 || j < 0 {
+		panic(fmt.Sprintf("index out of bounds: (%d, %d) in %v", i, j, A.Shape))
+	}
+	return A.array[i*A.Col+j]
+}
+
+// Copy 深拷贝
+func (A RatMatrix) Copy() RatMatrix {
+	return NewRatMatrix(A.Shape, A.array)
+}
+
+// Equal 判断两矩阵是否精确相等
+func (A RatMatrix) Equal(B RatMatrix) bool {
+	if ShapeNotEqual(A.Shape, B.Shape) {
+		return false
+	}
+	for i, v := range A.array {
+		if v.Cmp(B.array[i]) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// String 以 [a, b; c, d] 形式输出，非整数写作分数
+func (A RatMatrix) String() string {
+	rows := make([]string, A.Row)
+	cells := make([]string, A.Col)
+	for i := 0; i < A.Row; i++ {
+		for j := 0; j < A.Col; j++ {
+			cells[j] = A.at(i, j).RatString()
+		}
+		rows[i] = strings.Join(cells, ", ")
+	}
+	return "[" + strings.Join(rows, "; ") + "]"
+}
+
+// Add 矩阵相加
+func (A RatMatrix) Add(B RatMatrix) (S RatMatrix) {
+	if ShapeNotEqual(A.Shape, B.Shape) {
+		panic(fmt.Sprintf("two matrix cannot [add]. %v x %v", A.Shape, B.Shape))
+	}
+	S = RatZeros(A.Shape)
+	for i := range S.array {
+		S.array[i].Add(A.array[i], B.array[i])
+	}
+	return
+}
+
+// Sub 矩阵相减
+func (A RatMatrix) Sub(B RatMatrix) (S RatMatrix) {
+	if ShapeNotEqual(A.Shape, B.Shape) {
+		panic(fmt.Sprintf("two matrix cannot [sub]. %v x %v", A.Shape, B.Shape))
+	}
+	S = RatZeros(A.Shape)
+	for i := range S.array {
+		S.array[i].Sub(A.array[i], B.array[i])
+	}
+	return
+}
+
+// ScaleMul 矩阵比例乘
+func (A RatMatrix) ScaleMul(k *big.Rat) (S RatMatrix) {
+	S = RatZeros(A.Shape)
+	for i := range S.array {
+		S.array[i].Mul(A.array[i], k)
+	}
+	return
+}
+
+// Dot 矩阵乘法
+func (A RatMatrix) Dot(B RatMatrix) (S RatMatrix) {
+	if A.Col != B.Row {
+		panic(fmt.Sprintf("two matrix cannot [dot]. %v x %v", A.Shape, B.Shape))
+	}
+	S = RatZeros(Shape{A.Row, B.Col})
+	t := new(big.Rat)
+	for i := 0; i < S.Row; i++ {
+		for j := 0; j < S.Col; j++ {
+			v := S.at(i, j)
+			for k := 0; k < A.Col; k++ {
+				v.Add(v, t.Mul(A.at(i, k), B.at(k, j)))
+			}
+		}
+	}
+	return
+}
+
+// T 转置
+func (A RatMatrix) T() (S RatMatrix) {
+	S = RatZeros(Shape{A.Col, A.Row})
+	for i := 0; i < A.Row; i++ {
+		for j := 0; j < A.Col; j++ {
+			S.at(j, i).Set(A.at(i, j))
+		}
+	}
+	return
+}
+
+// Det Bareiss 无分数消元求行列式，中间结果的规模保持为子式大小
+func (A RatMatrix) Det() *big.Rat {
+	if A.Row != A.Col {
+		panic("Det(): matrix must be square.")
+	}
+	n := A.Row
+	M := A.Copy()
+	det := big.NewRat(1, 1)
+	prev := big.NewRat(1, 1)
+	t := new(big.Rat)
+
+	for k := 0; k < n-1; k++ {
+		if M.at(k, k).Sign() == 0 {
+			p := k + 1
+			for p < n && M.at(p, k).Sign() == 0 {
+				p++
+			}
+			if p == n {
+				return new(big.Rat)
+			}
+			M.swapRows(k, p)
+			det.Neg(det)
+		}
+		for i := k + 1; i < n; i++ {
+			for j := k + 1; j < n; j++ {
+				v := M.at(i, j)
+				v.Mul(v, M.at(k, k))
+				v.Sub(v, t.Mul(M.at(i, k), M.at(k, j)))
+				v.Quo(v, prev)
+			}
+		}
+		prev.Set(M.at(k, k))
+	}
+	if n > 0 {
+		det.Mul(det, M.at(n-1, n-1))
+	}
+	return det
+}
+
+// RREF 高斯-约当消元求行最简形，pivots 为各主元所在列
+func (A RatMatrix) RREF() (R RatMatrix, pivots []int) {
+	R = A.Copy()
+	pivots = R.eliminate(R.Col)
+	return
+}
+
+// Rank 秩
+func (A RatMatrix) Rank() int {
+	_, pivots := A.RREF()
+	return len(pivots)
+}
+
+// Inv 逆矩阵，A 奇异时以 ErrSingular panic
+func (A RatMatrix) Inv() RatMatrix {
+	if A.Row != A.Col {
+		panic("Inv(): matrix must be square.")
+	}
+	return A.Solve(RatEye(A.Row))
+}
+
+// Solve 求解线性方程组 AX = B，B 可以有多列。A 奇异时以 ErrSingular panic
+func (A RatMatrix) Solve(B RatMatrix) RatMatrix {
+	if A.Col != A.Row {
+		panic("Solve(B): matrix A must be square.")
+	}
+	if A.Row != B.Row {
+		panic(fmt.Sprintf("Solve(B): shape not match. %v x %v", A.Shape, B.Shape))
+	}
+
+	n := A.Row
+	M := RatZeros(Shape{n, n + B.Col})
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			M.at(i, j).Set(A.at(i, j))
+		}
+		for j := 0; j < B.Col; j++ {
+			M.at(i, n+j).Set(B.at(i, j))
+		}
+	}
+	if len(M.eliminate(n)) < n {
+		panic(ErrSingular)
+	}
+
+	X := RatZeros(B.Shape)
+	for i := 0; i < n; i++ {
+		for j := 0; j < B.Col; j++ {
+			X.at(i, j).Set(M.at(i, n+j))
+		}
+	}
+	return X
+}
+
+// eliminate 原地高斯-约当消元，只在前 ncols 列中选主元，返回主元所在列
+func (A RatMatrix) eliminate(ncols int) (pivots []int) {
+	t := new(big.Rat)
+	r := 0
+	for j := 0; j < ncols && r < A.Row; j++ {
+		p := r
+		for p < A.Row && A.at(p, j).Sign() == 0 {
+			p++
+		}
+		if p == A.Row {
+			continue
+		}
+		A.swapRows(r, p)
+
+		inv := new(big.Rat).Inv(A.at(r, j))
+		for k := j; k < A.Col; k++ {
+			A.at(r, k).Mul(A.at(r, k), inv)
+		}
+		for i := 0; i < A.Row; i++ {
+			c := A.at(i, j)
+			if i == r || c.Sign() == 0 {
+				continue
+			}
+			c = new(big.Rat).Set(c)
+			for k := j; k < A.Col; k++ {
+				v := A.at(i, k)
+				v.Sub(v, t.Mul(c, A.at(r, k)))
+			}
+		}
+		pivots = append(pivots, j)
+		r++
+	}
+	return
+}
+
+// swapRows 原地交换两行
+func (A RatMatrix) swapRows(i, j int) {
+	if i == j {
+		return
+	}
+	for k := 0; k < A.Col; k++ {
+		A.array[i*A.Col+k], A.array[j*A.Col+k] = A.array[j*A.Col+k], A.array[i*A.Col+k]
+	}
+}
@@ -0,0 +1,100 @@
+package matrix
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func hilbert(n int) RatMatrix {
+	H := RatZeros(Shape{n, n})
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			H.Set(i, j, big.NewRat(1, int64(i+j+1)))
+		}
+	}
+	return H
+}
+
+func TestRatDet(t *testing.T) {
+	cases := []struct {
+		A    RatMatrix
+		want *big.Rat
+	}{
+		{hilbert(4), big.NewRat(1, 6048000)},
+		{hilbert(6), big.NewRat(1, 186313420339200000)},
+		{RatFromMatrix(Builder().Row().Link(1, 2, 3).Link(4, 5, 6).Link(7, 8, 9).Build()), new(big.Rat)},
+		// 第一个主元为 0，需要换行
+		{RatFromMatrix(Builder().Row().Link(0, 1).Link(1, 0).Build()), big.NewRat(-1, 1)},
+		{RatFromMatrix(Builder().Row().Link(2, 0, 1).Link(1, 3, 2).Link(1, 1, 2).Build()), big.NewRat(6, 1)},
+		{RatZeros(Shape{0, 0}), big.NewRat(1, 1)},
+	}
+	for _, c := range cases {
+		if got := c.A.Det(); got.Cmp(c.want) != 0 {
+			t.Errorf("Det(%v) = %v, want %v", c.A, got.RatString(), c.want.RatString())
+		}
+	}
+}
+
+func TestRatInvSolve(t *testing.T) {
+	H := hilbert(5)
+	Hi := H.Inv()
+	if !H.Dot(Hi).Equal(RatEye(5)) || !Hi.Dot(H).Equal(RatEye(5)) {
+		t.Fatalf("Hilbert inverse is not exact: %v", Hi)
+	}
+	// 希尔伯特矩阵的逆为整数矩阵
+	if v := Hi.Get(4, 4); v.Cmp(big.NewRat(44100, 1)) != 0 {
+		t.Errorf("Inv(H5)[4, 4] = %v, want 44100", v.RatString())
+	}
+
+	b := RatFromMatrix(NewVector([]float64{1, 0, 0, 0, 0}, 1))
+	if x := H.Solve(b); !x.Equal(Hi.Dot(b)) {
+		t.Errorf("Solve: got %v, want first column of inverse", x)
+	}
+
+	defer func() {
+		if err, ok := recover().(error); !ok || !errors.Is(err, ErrSingular) {
+			t.Errorf("Inv of singular matrix: recovered %v, want ErrSingular", err)
+		}
+	}()
+	RatFromMatrix(Builder().Row().Link(1, 2).Link(2, 4).Build()).Inv()
+}
+
+func TestRatRREF(t *testing.T) {
+	A := RatFromMatrix(Builder().Row().Link(1, 2, 1, 4).Link(2, 4, 0, 6).Link(3, 6, 1, 10).Build())
+	R, pivots := A.RREF()
+	want := NewRatMatrix(Shape{3, 4}, []*big.Rat{
+		big.NewRat(1, 1), big.NewRat(2, 1), nil, big.NewRat(3, 1),
+		nil, nil, big.NewRat(1, 1), big.NewRat(1, 1),
+		nil, nil, nil, nil,
+	})
+	if !R.Equal(want) || len(pivots) != 2 || pivots[0] != 0 || pivots[1] != 2 {
+		t.Errorf("RREF = %v pivots %v, want %v pivots [0 2]", R, pivots, want)
+	}
+	if A.Rank() != 2 {
+		t.Errorf("Rank = %d, want 2", A.Rank())
+	}
+	// 参数不被修改
+	if A.Get(0, 3).Cmp(big.NewRat(4, 1)) != 0 {
+		t.Error("RREF modified its receiver")
+	}
+}
+
+func TestRatConversion(t *testing.T) {
+	A := Builder().Row().Link(0.1, -2.5).Link(1e-300, 3).Build()
+	R := RatFromMatrix(A)
+	if !MatrixEqual(R.Matrix(), A) || R.Matrix().Get(0, 0) != 0.1 {
+		t.Errorf("round trip: %v", R.Matrix())
+	}
+	if got := R.Get(0, 1); got.Cmp(big.NewRat(-5, 2)) != 0 {
+		t.Errorf("Get(0, 1) = %v, want -5/2", got.RatString())
+	}
+	if s := RatFromMatrix(Builder().Row().Link(0.5, -2).Build()).String(); s != "[1/2, -2]" {
+		t.Errorf("String() = %q", s)
+	}
+
+	S := R.Add(R).Sub(R).ScaleMul(big.NewRat(2, 1)).T()
+	if !S.T().Equal(R.ScaleMul(big.NewRat(2, 1))) {
+		t.Errorf("Add/Sub/ScaleMul/T: %v", S)
+	}
+}
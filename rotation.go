@@ -0,0 +1,346 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+)
+
+// EulerOrder 欧拉角的转轴顺序。均为内旋（绕随体坐标轴依次转动），
+// 如 EulerZYX 表示 R = Rz(a)·Ry(b)·Rx(c)；外旋序列等价于转轴与角度同时逆序的内旋
+type EulerOrder int
+
+const (
+	EulerXYZ EulerOrder = iota
+	EulerXZY
+	EulerYXZ
+	EulerYZX
+	EulerZXY
+	EulerZYX
+	EulerXYX
+	EulerXZX
+	EulerYXY
+	EulerYZY
+	EulerZXZ
+	EulerZYZ
+)
+
+var eulerAxes = [...][3]int{
+	{0, 1, 2}, {0, 2, 1}, {1, 0, 2}, {1, 2, 0}, {2, 0, 1}, {2, 1, 0},
+	{0, 1, 0}, {0, 2, 0}, {1, 0, 1}, {1, 2, 1}, {2, 0, 2}, {2, 1, 2},
+}
+
+func (o EulerOrder) String() string {
+	if o < 0 || int(o) >= len(eulerAxes) {
+		return fmt.Sprintf("EulerOrder(%d)", int(o))
+	}
+	b := make([]byte, 3)
+	for n, i := range eulerAxes[o] {
+		b[n] = "XYZ"[i]
+	}
+	return string(b)
+}
+
+// sequence 三次转动的轴序号，0、1、2 分别为 x、y、z
+func (o EulerOrder) sequence() [3]int {
+	if o < 0 || int(o) >= len(eulerAxes) {
+		panic(fmt.Sprintf("invalid euler order: %d", int(o)))
+	}
+	return eulerAxes[o]
+}
+
+// axes 返回第一、第二转轴与剩余的轴，s 为 (i, j, k) 的置换符号
+func (o EulerOrder) axes() (i, j, k int, s float64) {
+	seq := o.sequence()
+	i, j = seq[0], seq[1]
+	k = 3 - i - j
+	s = 1
+	if (j-i+3)%3 != 1 {
+		s = -1
+	}
+	return
+}
+
+// RotationX 绕 x 轴旋转 theta 弧度
+func RotationX(theta float64) Mat3 { return axisRotation(0, theta) }
+
+// RotationY 绕 y 轴旋转 theta 弧度
+func RotationY(theta float64) Mat3 { return axisRotation(1, theta) }
+
+// RotationZ 绕 z 轴旋转 theta 弧度
+func RotationZ(theta float64) Mat3 { return axisRotation(2, theta) }
+
+func axisRotation(i int, theta float64) Mat3 {
+	j, k := (i+1)%3, (i+2)%3
+	c, s := math.Cos(theta), math.Sin(theta)
+	R := Ident3()
+	R[j][j], R[j][k] = c, -s
+	R[k][j], R[k][k] = s, c
+	return R
+}
+
+// RotationAxisAngle Rodrigues 公式，绕 axis 旋转 angle 弧度，axis 不必为单位向量
+func RotationAxisAngle(axis Vec3, angle float64) Mat3 {
+	u := axis.Normalize()
+	c, s := math.Cos(angle), math.Sin(angle)
+	K := Mat3{{0, -u[2], u[1]}, {u[2], 0, -u[0]}, {-u[1], u[0], 0}}
+	// R = I + sinθ K + (1 - cosθ) K²
+	return Ident3().Add(K.Scale(s)).Add(K.Mul(K).Scale(1 - c))
+}
+
+// RotationEuler 由欧拉角构造旋转矩阵，R = R_i(a)·R_j(b)·R_k(c)
+func RotationEuler(order EulerOrder, a, b, c float64) Mat3 {
+	ax := order.sequence()
+	return axisRotation(ax[0], a).Mul(axisRotation(ax[1], b)).Mul(axisRotation(ax[2], c))
+}
+
+// Euler 分解为指定顺序的欧拉角。a、c ∈ (-π, π]，
+// 第二个角 b 对 Tait-Bryan 顺序位于 [-π/2, π/2]，对经典欧拉顺序位于 [0, π]。
+// 万向锁时取 c = 0
+func (R Mat3) Euler(order EulerOrder) (a, b, c float64) {
+	i, j, k, s := order.axes()
+	const eps = 1e-12
+
+	if order.sequence()[2] == i {
+		// R = R_i(a)·R_j(b)·R_i(c)
+		sb := math.Hypot(R[i][j], R[i][k])
+		b = math.Atan2(sb, R[i][i])
+		if sb < eps {
+			return math.Atan2(s*R[k][j], R[j][j]), b, 0
+		}
+		a = math.Atan2(R[j][i], -s*R[k][i])
+		c = math.Atan2(R[i][j], s*R[i][k])
+		return
+	}
+
+	// R = R_i(a)·R_j(b)·R_k(c)
+	sb := math.Max(-1, math.Min(1, s*R[i][k]))
+	b = math.Asin(sb)
+	if math.Abs(math.Abs(sb)-1) < eps {
+		return math.Atan2(s*R[k][j], R[j][j]), b, 0
+	}
+	a = math.Atan2(-s*R[j][k], R[k][k])
+	c = math.Atan2(-s*R[i][j], R[i][i])
+	return
+}
+
+// AxisAngle 分解为单位转轴与 [0, π] 内的转角，转角为 0 时转轴取 x 轴
+func (R Mat3) AxisAngle() (axis Vec3, angle float64) {
+	return QuatFromMat3(R).AxisAngle()
+}
+
+// Orthonormalize 极分解 R = U·P 中的正交因子 U，即 Frobenius 范数下与 R 最接近的正交矩阵，
+// 用于修正累积误差导致的漂移。使用 Newton 迭代 U ← (U + U⁻ᵀ) / 2，R 奇异时以 ErrSingular panic
+func (R Mat3) Orthonormalize() Mat3 {
+	U := R
+	for it := 0; it < 100; it++ {
+		next := U.Add(U.Inverse().Transpose()).Scale(0.5)
+		diff := 0.0
+		for i := range U {
+			for j := range U[i] {
+				diff = math.Max(diff, math.Abs(next[i][j]-U[i][j]))
+			}
+		}
+		U = next
+		if diff < 1e-15 {
+			break
+		}
+	}
+	return U
+}
+
+// Quat 单位四元数 W + Xi + Yj + Zk 表示旋转
+type Quat struct {
+	W, X, Y, Z float64
+}
+
+// QuatIdent 单位四元数，表示不旋转
+func QuatIdent() Quat { return Quat{W: 1} }
+
+// QuatFromAxisAngle 绕 axis 旋转 angle 弧度，axis 不必为单位向量
+func QuatFromAxisAngle(axis Vec3, angle float64) Quat {
+	u := axis.Normalize().Scale(math.Sin(angle / 2))
+	return Quat{math.Cos(angle / 2), u[0], u[1], u[2]}
+}
+
+// QuatFromEuler 由欧拉角构造，与 RotationEuler 的约定一致
+func QuatFromEuler(order EulerOrder, a, b, c float64) Quat {
+	ax := order.sequence()
+	var q [3]Quat
+	for n, ang := range [3]float64{a, b, c} {
+		var axis Vec3
+		axis[ax[n]] = 1
+		q[n] = QuatFromAxisAngle(axis, ang)
+	}
+	return q[0].Mul(q[1]).Mul(q[2])
+}
+
+// QuatFromMat3 由旋转矩阵构造（Shepperd 方法），结果 W ≥ 0
+func QuatFromMat3(R Mat3) (q Quat) {
+	tr := R[0][0] + R[1][1] + R[2][2]
+	switch {
+	case tr > R[0][0] && tr > R[1][1] && tr > R[2][2]:
+		s := 2 * math.Sqrt(1+tr)
+		q = Quat{s / 4, (R[2][1] - R[1][2]) / s, (R[0][2] - R[2][0]) / s, (R[1][0] - R[0][1]) / s}
+	case R[0][0] >= R[1][1] && R[0][0] >= R[2][2]:
+		s := 2 * math.Sqrt(1+2*R[0][0]-tr)
+		q = Quat{(R[2][1] - R[1][2]) / s, s / 4, (R[0][1] + R[1][0]) / s, (R[0][2] + R[2][0]) / s}
+	case R[1][1] >= R[2][2]:
+		s := 2 * math.Sqrt(1+2*R[1][1]-tr)
+		q = Quat{(R[0][2] - R[2][0]) / s, (R[0][1] + R[1][0]) / s, s / 4, (R[1][2] + R[2][1]) / s}
+	default:
+		s := 2 * math.Sqrt(1+2*R[2][2]-tr)
+		q = Quat{(R[1][0] - R[0][1]) / s, (R[0][2] + R[2][0]) / s, (R[1][2] + R[2][1]) / s, s / 4}
+	}
+	if q.W < 0 {
+		q = q.Scale(-1)
+	}
+	return q.Normalize()
+}
+
+// Scale 比例乘
+func (q Quat) Scale(k float64) Quat { return Quat{q.W * k, q.X * k, q.Y * k, q.Z * k} }
+
+// Dot 内积
+func (q Quat) Dot(p Quat) float64 { return q.W*p.W + q.X*p.X + q.Y*p.Y + q.Z*p.Z }
+
+// Norm 模长
+func (q Quat) Norm() float64 { return math.Sqrt(q.Dot(q)) }
+
+// Normalize 单位化
+func (q Quat) Normalize() Quat {
+	if n := q.Norm(); n != 0 {
+		return q.Scale(1 / n)
+	}
+	return q
+}
+
+// Conj 共轭，对单位四元数即逆旋转
+func (q Quat) Conj() Quat { return Quat{q.W, -q.X, -q.Y, -q.Z} }
+
+// Mul Hamilton 积，q.Mul(p) 表示先做 p 再做 q
+func (q Quat) Mul(p Quat) Quat {
+	return Quat{
+		q.W*p.W - q.X*p.X - q.Y*p.Y - q.Z*p.Z,
+		q.W*p.X + q.X*p.W + q.Y*p.Z - q.Z*p.Y,
+		q.W*p.Y - q.X*p.Z + q.Y*p.W + q.Z*p.X,
+		q.W*p.Z + q.X*p.Y - q.Y*p.X + q.Z*p.W,
+	}
+}
+
+// Rotate 旋转向量
+func (q Quat) Rotate(v Vec3) Vec3 {
+	// v' = v + 2u × (u × v + w v)
+	u := Vec3{q.X, q.Y, q.Z}
+	t := u.Cross(v).Add(v.Scale(q.W))
+	return v.Add(u.Cross(t).Scale(2))
+}
+
+// Mat3 转换为旋转矩阵
+func (q Quat) Mat3() Mat3 {
+	w, x, y, z := q.W, q.X, q.Y, q.Z
+	return Mat3{
+		{1 - 2*(y*y+z*z), 2 * (x*y - w*z), 2 * (x*z + w*y)},
+		{2 * (x*y + w*z), 1 - 2*(x*x+z*z), 2 * (y*z - w*x)},
+		{2 * (x*z - w*y), 2 * (y*z + w*x), 1 - 2*(x*x+y*y)},
+	}
+}
+
+// AxisAngle 分解为单位转轴与 [0, π] 内的转角，转角为 0 时转轴取 x 轴
+func (q Quat) AxisAngle() (axis Vec3, angle float64) {
+	if q.W < 0 {
+		q = q.Scale(-1)
+	}
+	u := Vec3{q.X, q.Y, q.Z}
+	s := u.Norm()
+	if s < 1e-15 {
+		return Vec3{1, 0, 0}, 0
+	}
+	return u.Scale(1 / s), 2 * math.Atan2(s, q.W)
+}
+
+// Euler 分解为欧拉角，见 Mat3.Euler
+func (q Quat) Euler(order EulerOrder) (a, b, c float64) {
+	return q.Mat3().Euler(order)
+}
+
+// Slerp 球面线性插值，t = 0 时为 p，t = 1 时为 q，沿最短弧插值
+func Slerp(p, q Quat, t float64) Quat {
+	d := p.Dot(q)
+	if d < 0 {
+		q, d = q.Scale(-1), -d
+	}
+	if d > 0.9995 {
+		// 夹角很小时退化为线性插值
+		return Quat{
+			p.W + t*(q.W-p.W), p.X + t*(q.X-p.X), p.Y + t*(q.Y-p.Y), p.Z + t*(q.Z-p.Z),
+		}.Normalize()
+	}
+	theta := math.Acos(d)
+	sp, sq := math.Sin((1-t)*theta), math.Sin(t*theta)
+	s := math.Sin(theta)
+	return Quat{
+		(sp*p.W + sq*q.W) / s, (sp*p.X + sq*q.X) / s, (sp*p.Y + sq*q.Y) / s, (sp*p.Z + sq*q.Z) / s,
+	}
+}
+
+// Transform 刚体变换 x ↦ R·x + T
+type Transform struct {
+	R Mat3
+	T Vec3
+}
+
+// IdentTransform 恒等变换
+func IdentTransform() Transform { return Transform{R: Ident3()} }
+
+// TransformFromMat4 由 4x4 齐次矩阵构造，最后一行不是 [0, 0, 0, 1] 时 panic
+func TransformFromMat4(M Mat4) (X Transform) {
+	if M[3] != [4]float64{0, 0, 0, 1} {
+		panic(fmt.Sprintf("TransformFromMat4(M): last row must be [0, 0, 0, 1], got %v.", M[3]))
+	}
+	for i := 0; i < 3; i++ {
+		copy(X.R[i][:], M[i][:3])
+		X.T[i] = M[i][3]
+	}
+	return
+}
+
+// Mat4 转换为 4x4 齐次矩阵
+func (X Transform) Mat4() (M Mat4) {
+	for i := 0; i < 3; i++ {
+		copy(M[i][:3], X.R[i][:])
+		M[i][3] = X.T[i]
+	}
+	M[3][3] = 1
+	return
+}
+
+// Compose 复合变换，X.Compose(Y) 表示先做 Y 再做 X
+func (X Transform) Compose(Y Transform) Transform {
+	return Transform{R: X.R.Mul(Y.R), T: X.R.MulVec(Y.T).Add(X.T)}
+}
+
+// Inverse 逆变换，要求 R 为正交矩阵
+func (X Transform) Inverse() Transform {
+	Rt := X.R.Transpose()
+	return Transform{R: Rt, T: Rt.MulVec(X.T).Scale(-1)}
+}
+
+// ApplyVec 变换单个点
+func (X Transform) ApplyVec(v Vec3) Vec3 {
+	return X.R.MulVec(v).Add(X.T)
+}
+
+// Apply 变换点集，P 的每一列为一个点，形状须为 3xN
+func (X Transform) Apply(P Matrix) Matrix {
+	if P.Row != 3 {
+		panic(fmt.Sprintf("Apply(P): P must be 3xN, got %v.", P.Shape))
+	}
+	S := Zeros(P.Shape)
+	for j := 0; j < P.Col; j++ {
+		v := X.ApplyVec(Vec3{P.Get(0, j), P.Get(1, j), P.Get(2, j)})
+		for i := range v {
+			S.Set(i, j, v[i])
+		}
+	}
+	return S
+}
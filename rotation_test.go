@@ -0,0 +1,154 @@
+package matrix
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestRotationAxisAngle(t *testing.T) {
+	R := RotationAxisAngle(Vec3{0, 0, 2}, math.Pi/2)
+	RExpected := Builder().Row().Link(0, -1, 0).Link(1, 0, 0).Link(0, 0, 1).Build()
+
+	if !MatrixEqual(RExpected, R.Matrix()) {
+		t.Errorf("error method: RotationAxisAngle, got %v, want %v", R, RExpected)
+	}
+
+	if want := RotationZ(math.Pi / 2); !MatrixEqual(want.Matrix(), R.Matrix()) {
+		t.Errorf("error method: RotationAxisAngle, got %v, want %v", R, want)
+	}
+
+	VExpected := Builder().Col().Link(0, 1, 0).Build()
+	if got := R.MulVec(Vec3{1, 0, 0}).Matrix(); !MatrixEqual(VExpected, got) {
+		t.Errorf("error method: Mat3.MulVec, got %v, want %v", got, VExpected)
+	}
+
+	axis := Vec3{1, -2, 3}.Normalize()
+	R = RotationAxisAngle(axis, 2.5)
+	gotAxis, gotAngle := R.AxisAngle()
+	if math.Abs(gotAngle-2.5) > 1e-9 || !MatrixEqual(axis.Matrix(), gotAxis.Matrix()) {
+		t.Errorf("error method: AxisAngle, got %v, %v, want %v, 2.5", gotAxis, gotAngle, axis)
+	}
+
+	if got := QuatFromAxisAngle(axis, 2.5).Mat3(); !MatrixEqual(R.Matrix(), got.Matrix()) {
+		t.Errorf("error method: QuatFromAxisAngle, got %v, want %v", got, R)
+	}
+}
+
+func TestRotationEuler(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	for o := EulerXYZ; o <= EulerZYZ; o++ {
+		proper := o >= EulerXYX
+		for n := 0; n < 20; n++ {
+			a := (2*src.Float64() - 1) * math.Pi
+			b := (src.Float64() - 0.5) * math.Pi
+			if proper {
+				b = src.Float64() * math.Pi
+			}
+			c := (2*src.Float64() - 1) * math.Pi
+			R := RotationEuler(o, a, b, c)
+			if got := QuatFromEuler(o, a, b, c).Mat3(); !MatrixEqual(R.Matrix(), got.Matrix()) {
+				t.Errorf("error method: QuatFromEuler %v, got %v, want %v", o, got, R)
+			}
+			ga, gb, gc := R.Euler(o)
+			if math.Abs(ga-a) > 1e-9 || math.Abs(gb-b) > 1e-9 || math.Abs(gc-c) > 1e-9 {
+				t.Errorf("error method: Euler %v, got %v, %v, %v, want %v, %v, %v", o, ga, gb, gc, a, b, c)
+			}
+		}
+
+		// 万向锁时只要求矩阵一致
+		b := math.Pi / 2
+		if proper {
+			b = 0
+		}
+		R := RotationEuler(o, 0.3, b, 0.4)
+		ga, gb, gc := R.Euler(o)
+		if !MatrixEqual(R.Matrix(), RotationEuler(o, ga, gb, gc).Matrix()) || gc != 0 {
+			t.Errorf("error method: Euler %v gimbal lock, got %v, %v, %v", o, ga, gb, gc)
+		}
+	}
+
+	if got := EulerZYZ.String(); got != "ZYZ" {
+		t.Errorf("error method: EulerOrder.String, got %q, want ZYZ", got)
+	}
+}
+
+func TestQuat(t *testing.T) {
+	p := QuatFromAxisAngle(Vec3{0, 0, 1}, 0)
+	q := QuatFromAxisAngle(Vec3{0, 0, 1}, math.Pi/2)
+
+	if got, want := Slerp(p, q, 0.5).Mat3(), RotationZ(math.Pi/4); !MatrixEqual(want.Matrix(), got.Matrix()) {
+		t.Errorf("error method: Slerp, got %v, want %v", got, want)
+	}
+
+	// 沿最短弧：-q 与 q 表示同一旋转
+	if got := Slerp(p, q.Scale(-1), 1).Mat3(); !MatrixEqual(q.Mat3().Matrix(), got.Matrix()) {
+		t.Errorf("error method: Slerp shortest arc, got %v, want %v", got, q.Mat3())
+	}
+
+	r := QuatFromEuler(EulerZYX, 0.1, -0.7, 2.9)
+	if got := QuatFromMat3(r.Mat3()); math.Abs(math.Abs(got.Dot(r))-1) > 1e-12 {
+		t.Errorf("error method: QuatFromMat3, got %v, want ±%v", got, r)
+	}
+
+	v := Vec3{1, 2, 3}
+	if got, want := r.Rotate(v), r.Mat3().MulVec(v); !MatrixEqual(want.Matrix(), got.Matrix()) {
+		t.Errorf("error method: Quat.Rotate, got %v, want %v", got, want)
+	}
+
+	if got, want := r.Mul(q).Mat3(), r.Mat3().Mul(q.Mat3()); !MatrixEqual(want.Matrix(), got.Matrix()) {
+		t.Errorf("error method: Quat.Mul, got %v, want %v", got, want)
+	}
+
+	if got := r.Mul(r.Conj()).Mat3(); !MatrixEqual(Eye(3), got.Matrix()) {
+		t.Errorf("error method: Quat.Conj, q·q* = %v, want I", got)
+	}
+}
+
+func TestOrthonormalize(t *testing.T) {
+	R := RotationEuler(EulerXYZ, 0.4, -1.1, 2.3)
+	D := R
+	D[0][1] += 1e-3
+	D[2][0] -= 2e-3
+	U := D.Orthonormalize()
+
+	if !MatrixEqual(Eye(3), U.Mul(U.Transpose()).Matrix()) || math.Abs(U.Det()-1) > 1e-12 {
+		t.Errorf("error method: Orthonormalize, %v is not a rotation", U)
+	}
+
+	if got := R.Orthonormalize(); !MatrixEqual(R.Matrix(), got.Matrix()) {
+		t.Errorf("error method: Orthonormalize, got %v, want %v", got, R)
+	}
+}
+
+func TestTransform(t *testing.T) {
+	X := Transform{R: RotationZ(math.Pi / 2), T: Vec3{1, 0, 0}}
+	Y := Transform{R: RotationX(0.3), T: Vec3{0, 2, -1}}
+	P := Builder().Row().Link(1, 0, 2).Link(0, 1, 3).Link(0, 0, 4).Build()
+
+	if got, want := X.Compose(Y).Mat4(), X.Mat4().Mul(Y.Mat4()); !MatrixEqual(want.Matrix(), got.Matrix()) {
+		t.Errorf("error method: Transform.Compose, got %v, want %v", got, want)
+	}
+
+	if got, want := X.Compose(Y).Apply(P), X.Apply(Y.Apply(P)); !MatrixEqual(want, got) {
+		t.Errorf("error method: Transform.Compose, got %v, want %v", got, want)
+	}
+
+	if got := X.Inverse().Apply(X.Apply(P)); !MatrixEqual(P, got) {
+		t.Errorf("error method: Transform.Inverse, got %v, want %v", got, P)
+	}
+
+	if got, want := X.Inverse().Mat4(), X.Mat4().Inverse(); !MatrixEqual(want.Matrix(), got.Matrix()) {
+		t.Errorf("error method: Transform.Inverse, got %v, want %v", got, want)
+	}
+
+	// 每列绕 z 轴转 90° 后沿 x 平移 1
+	PExpected := Builder().Row().Link(1, 0, -2).Link(1, 0, 2).Link(0, 0, 4).Build()
+	if got := X.Apply(P); !MatrixEqual(PExpected, got) {
+		t.Errorf("error method: Transform.Apply, got %v, want %v", got, PExpected)
+	}
+
+	if got := TransformFromMat4(X.Mat4()); got != X {
+		t.Errorf("error method: TransformFromMat4, got %v, want %v", got, X)
+	}
+}
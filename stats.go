@@ -0,0 +1,193 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+)
+
+// Mean 按列求均值，返回行向量
+func Mean(X Matrix) Matrix {
+	M := Zeros(Shape{1, X.Col})
+	if X.Row == 0 {
+		return M
+	}
+	for j := 0; j < X.Col; j++ {
+		v := 0.0
+		for i := 0; i < X.Row; i++ {
+			v += X.Get(i, j)
+		}
+		M.SetIndex(j, v/float64(X.Row))
+	}
+	return M
+}
+
+// Center 中心化：每列减去该列均值
+func Center(X Matrix) Matrix {
+	return subRow(X, Mean(X))
+}
+
+// Standardize 标准化：每列减去均值并除以样本标准差。
+// 返回标准化结果、列均值 Mu 与列标准差 Sigma，标准差为 0 的列只做中心化
+func Standardize(X Matrix) (Z, Mu, Sigma Matrix) {
+	Mu = Mean(X)
+	Z = subRow(X, Mu)
+	Sigma = Zeros(Shape{1, X.Col})
+
+	for j := 0; j < X.Col; j++ {
+		v := 0.0
+		for i := 0; i < X.Row; i++ {
+			v += Z.Get(i, j) * Z.Get(i, j)
+		}
+		if X.Row > 1 {
+			v /= float64(X.Row - 1)
+		}
+		s := math.Sqrt(v)
+		Sigma.SetIndex(j, s)
+
+		if s == 0 {
+			continue
+		}
+		for i := 0; i < X.Row; i++ {
+			Z.Set(i, j, Z.Get(i, j)/s)
+		}
+	}
+	return
+}
+
+// Zscore 标准分数，等价于 Standardize 的第一个返回值
+func Zscore(X Matrix) Matrix {
+	Z, _, _ := Standardize(X)
+	return Z
+}
+
+// subRow 每行减去行向量 V
+func subRow(X, V Matrix) Matrix {
+	S := Zeros(X.Shape)
+	for i := 0; i < X.Row; i++ {
+		for j := 0; j < X.Col; j++ {
+			S.Set(i, j, X.Get(i, j)-V.GetIndex(j))
+		}
+	}
+	return S
+}
+
+// Cov 协方差矩阵（无偏估计）。
+// rowvar 为 true 时每行是一个变量、每列是一次观测；否则每列是一个变量
+func Cov(X Matrix, rowvar bool) Matrix {
+	if rowvar {
+		X = X.T()
+	}
+	if X.Row < 2 {
+		panic(fmt.Sprintf("Cov(X): need at least 2 observations, got %d.", X.Row))
+	}
+
+	C := Center(X)
+	S := C.T().Dot(C)
+	MatrixScaleMul(S, 1/float64(X.Row-1))
+	return S
+}
+
+// Corrcoef 相关系数矩阵，每列是一个变量（与 Matlab 一致）
+func Corrcoef(X Matrix) Matrix {
+	C := Cov(X, false)
+	n := C.Row
+
+	R := Zeros(C.Shape)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			d := math.Sqrt(C.Get(i, i) * C.Get(j, j))
+			R.Set(i, j, C.Get(i, j)/d)
+		}
+	}
+	return R
+}
+
+// PCA 主成分分析，样本按行排列
+type PCA struct {
+	// NComponents 保留的主成分个数，不大于 0 表示保留全部
+	NComponents int
+	// Mean 训练数据的列均值
+	Mean Matrix
+	// Components 主成分方向，每行一个单位向量，按方差降序排列
+	Components Matrix
+	// ExplainedVariance 各主成分方向上的方差
+	ExplainedVariance Matrix
+	// ExplainedVarianceRatio 各主成分方差占总方差的比例
+	ExplainedVarianceRatio Matrix
+}
+
+// NewPCA 新建主成分分析，k 为保留的主成分个数
+func NewPCA(k int) *PCA {
+	return &PCA{NComponents: k}
+}
+
+// Fit 由协方差矩阵的特征分解求主成分
+func (p *PCA) Fit(X Matrix) *PCA {
+	k := p.NComponents
+	if k <= 0 || k > X.Col {
+		k = X.Col
+	}
+
+	p.Mean = Mean(X)
+	D, V := EigSym(Cov(X, false))
+
+	total := 0.0
+	for j := 0; j < D.Size(); j++ {
+		total += math.Max(D.GetIndex(j), 0)
+	}
+
+	p.Components = Zeros(Shape{k, X.Col})
+	p.ExplainedVariance = Zeros(Shape{1, k})
+	p.ExplainedVarianceRatio = Zeros(Shape{1, k})
+	for j := 0; j < k; j++ {
+		v := V.GetCol(j)
+		// 固定符号：绝对值最大的分量为正
+		m := 0
+		for i := 1; i < v.Size(); i++ {
+			if math.Abs(v.GetIndex(i)) > math.Abs(v.GetIndex(m)) {
+				m = i
+			}
+		}
+		if v.GetIndex(m) < 0 {
+			MatrixScaleMul(v, -1)
+		}
+		p.Components.SetRow(j, v.T())
+
+		d := math.Max(D.GetIndex(j), 0)
+		p.ExplainedVariance.SetIndex(j, d)
+		if total > 0 {
+			p.ExplainedVarianceRatio.SetIndex(j, d/total)
+		}
+	}
+	return p
+}
+
+// Transform 将样本投影到主成分空间
+func (p *PCA) Transform(X Matrix) Matrix {
+	p.mustFit()
+	if X.Col != p.Mean.Size() {
+		panic(fmt.Sprintf("PCA.Transform(X): expect %d columns, got %d.", p.Mean.Size(), X.Col))
+	}
+	return subRow(X, p.Mean).Dot(p.Components.T())
+}
+
+// InverseTransform 由主成分坐标重建样本
+func (p *PCA) InverseTransform(Z Matrix) Matrix {
+	p.mustFit()
+	if Z.Col != p.Components.Row {
+		panic(fmt.Sprintf("PCA.InverseTransform(Z): expect %d columns, got %d.", p.Components.Row, Z.Col))
+	}
+	S := Z.Dot(p.Components)
+	for i := 0; i < S.Row; i++ {
+		for j := 0; j < S.Col; j++ {
+			S.Set(i, j, S.Get(i, j)+p.Mean.GetIndex(j))
+		}
+	}
+	return S
+}
+
+func (p *PCA) mustFit() {
+	if p.Components.Size() == 0 {
+		panic("PCA: call Fit before Transform.")
+	}
+}
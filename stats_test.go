@@ -0,0 +1,74 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCov(t *testing.T) {
+	X := Builder().Row().Link(1, 2).Link(2, 4).Link(3, 7).Build()
+	Expected := Builder().Row().Link(1, 2.5).Link(2.5, 6.333333333333333).Build()
+
+	if !MatrixEqual(Cov(X, false), Expected) || !MatrixEqual(Cov(X.T(), true), Expected) {
+		t.Error("error method: Cov")
+	}
+
+	R := Corrcoef(X)
+	if math.Abs(R.Get(0, 0)-1) > 1e-12 || math.Abs(R.Get(0, 1)-2.5/math.Sqrt(6.333333333333333)) > 1e-12 {
+		t.Error("error method: Corrcoef")
+	}
+}
+
+func TestStandardize(t *testing.T) {
+	X := Builder().Row().Link(1, 5).Link(2, 5).Link(3, 5).Build()
+	Z, Mu, Sigma := Standardize(X)
+
+	if !MatrixEqual(Z, Builder().Row().Link(-1, 0).Link(0, 0).Link(1, 0).Build()) {
+		t.Error("error method: Standardize")
+	}
+	if !MatrixEqual(Mu, NewVector([]float64{2, 5}, 2)) || !MatrixEqual(Sigma, NewVector([]float64{1, 0}, 2)) {
+		t.Error("error method: Standardize")
+	}
+	if !MatrixEqual(Center(X), Builder().Row().Link(-1, 0).Link(0, 0).Link(1, 0).Build()) {
+		t.Error("error method: Center")
+	}
+}
+
+func TestEigSym(t *testing.T) {
+	A := Builder().Row().Link(4, 1, 2).Link(1, 3, 0).Link(2, 0, 5).Build()
+	D, V := EigSym(A)
+
+	if !MatrixEqual(A.Dot(V), V.Dot(Diag([]float64{D.GetIndex(0), D.GetIndex(1), D.GetIndex(2)}))) {
+		t.Error("error method: EigSym")
+	}
+	if !MatrixEqual(V.T().Dot(V), Eye(3)) {
+		t.Error("error method: EigSym orthogonal")
+	}
+	if D.GetIndex(0) < D.GetIndex(1) || D.GetIndex(1) < D.GetIndex(2) {
+		t.Error("error method: EigSym order")
+	}
+}
+
+func TestPCA(t *testing.T) {
+	// 样本分布在直线 y = 2x 附近
+	X := Builder().Row().
+		Link(1, 2.1).
+		Link(2, 3.9).
+		Link(3, 6.2).
+		Link(4, 7.8).
+		Link(5, 10.1).Build()
+
+	p := NewPCA(1).Fit(X)
+	axis := p.Components.GetRow(0)
+	if math.Abs(axis.GetIndex(1)/axis.GetIndex(0)-2) > 0.05 {
+		t.Error("error method: PCA components")
+	}
+	if p.ExplainedVarianceRatio.GetIndex(0) < 0.99 {
+		t.Error("error method: PCA variance ratio")
+	}
+
+	full := NewPCA(0).Fit(X)
+	if !MatrixEqual(full.InverseTransform(full.Transform(X)), X) {
+		t.Error("error method: PCA InverseTransform")
+	}
+}